@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+type ContextKey string
+
+const (
+	AUDIT_CTX_ACTOR      ContextKey = "audit_actor"
+	AUDIT_CTX_REQUEST_ID ContextKey = "audit_request_id"
+
+	// CollectionID is the global storage collection audit entries are kept in
+	CollectionID = "admin_audit"
+)
+
+// Entry records a single change made through an admin api
+type Entry struct {
+	ID        string      `json:"id"`
+	Actor     string      `json:"actor"`
+	Action    string      `json:"action"`
+	Target    string      `json:"target"`
+	Before    interface{} `json:"before,omitempty"`
+	After     interface{} `json:"after,omitempty"`
+	Time      time.Time   `json:"time"`
+	RequestID string      `json:"requestId,omitempty"`
+}
+
+// WithActor returns a context attributing the changes made with it to actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, AUDIT_CTX_ACTOR, actor)
+}
+
+// Actor returns who the changes made with ctx are attributed to, falling back on the Nakama user calling the RPC
+func Actor(ctx context.Context) string {
+	if actor, ok := ctx.Value(AUDIT_CTX_ACTOR).(string); ok && actor != "" {
+		return actor
+	}
+	if username, ok := ctx.Value(runtime.RUNTIME_CTX_USERNAME).(string); ok && username != "" {
+		return username
+	}
+	return "unknown"
+}
+
+// WithRequestID returns a context grouping the changes made with it under the request id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, AUDIT_CTX_REQUEST_ID, id)
+}
+
+// EnsureRequestID returns ctx if it already carries a request id, or a context carrying a new one otherwise
+func EnsureRequestID(ctx context.Context) context.Context {
+	if RequestID(ctx) != "" {
+		return ctx
+	}
+	return WithRequestID(ctx, NewRequestID())
+}
+
+// RequestID returns the id of the request the changes made with ctx belong to, empty if there is none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(AUDIT_CTX_REQUEST_ID).(string)
+	return id
+}
+
+// NewRequestID creates a random request id
+func NewRequestID() string {
+	return hex.EncodeToString(randomBytes(8))
+}
+
+// Record stores an audit entry of action having been applied to target, attributed to the actor of ctx. Changes made
+// through storage writes or a transaction should rather be recorded along with them, with NewWrite or RecordTx.
+func Record(ctx context.Context, nk runtime.NakamaModule, action string, target string, before interface{}, after interface{}) error {
+
+	write, err := NewWrite(ctx, action, target, before, after)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{write})
+
+	return err
+}
+
+// NewWrite returns the storage write of an audit entry like Record stores, to write along with the writes of the
+// change it records in a single StorageWrite, which Nakama applies atomically
+func NewWrite(ctx context.Context, action string, target string, before interface{}, after interface{}) (*runtime.StorageWrite, error) {
+
+	entry, bytes, err := newEntry(ctx, action, target, before, after)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &runtime.StorageWrite{
+		Collection:      CollectionID,
+		Key:             entry.ID,
+		Value:           string(bytes),
+		PermissionRead:  0,
+		PermissionWrite: 0,
+	}, nil
+}
+
+// RecordTx stores an audit entry like Record within tx, for changes made to Nakama's tables in a transaction to be
+// recorded only if they are committed
+func RecordTx(ctx context.Context, tx *sql.Tx, action string, target string, before interface{}, after interface{}) error {
+
+	entry, bytes, err := newEntry(ctx, action, target, before, after)
+
+	if err != nil {
+		return err
+	}
+
+	// Versions are the md5 hash of the value, as Nakama computes them
+	version := md5.Sum(bytes)
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO storage (collection, key, user_id, value, version, read, write, create_time, update_time) VALUES ($1, $2, $3, $4, $5, 0, 0, now(), now())",
+		CollectionID, entry.ID, globalUserID, string(bytes), hex.EncodeToString(version[:]))
+
+	return err
+}
+
+func newEntry(ctx context.Context, action string, target string, before interface{}, after interface{}) (*Entry, []byte, error) {
+
+	now := time.Now().UTC()
+
+	entry := &Entry{
+		ID:        newID(now),
+		Actor:     Actor(ctx),
+		Action:    action,
+		Target:    target,
+		Before:    before,
+		After:     after,
+		Time:      now,
+		RequestID: RequestID(ctx),
+	}
+
+	bytes, err := json.Marshal(entry)
+
+	return entry, bytes, err
+}
+
+// newID creates keys that sort in the order entries were recorded
+func newID(t time.Time) string {
+	return fmt.Sprintf("%s-%s", timeKey(t), hex.EncodeToString(randomBytes(4)))
+}
+
+// timeKey is the prefix of the keys of entries recorded at t, which keys of entries recorded earlier sort before
+func timeKey(t time.Time) string {
+	return fmt.Sprintf("%019d", t.UnixNano())
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
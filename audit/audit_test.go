@@ -0,0 +1,124 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc"
+	"github.com/mastern2k3/poseidon/rpc/rpctest"
+	"github.com/mastern2k3/poseidon/tests/fake"
+)
+
+func TestMiddleware(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+
+	init := rpctest.New(t, nk)
+	routes := []rpc.RPCRoute{
+		&rpc.StringRoute{Name: "grant", Handler: func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+			return "", nil
+		}},
+	}
+	if err := rpc.RegisterRoutes(init, routes, Middleware(nk)); err != nil {
+		t.Fatalf("error while registering routes: %s", err)
+	}
+
+	ctx := WithRequestID(rpctest.WithUserID(context.Background(), "some-user-id", "someone"), "some-request")
+	if _, err := init.Call(ctx, "grant", `{"item":"sword"}`); err != nil {
+		t.Fatalf("error while calling: %s", err)
+	}
+
+	objs, _, err := nk.StorageList(context.Background(), "", CollectionID, 10, "")
+	if err != nil || len(objs) != 1 {
+		t.Fatalf("expected a single audit entry but got %+v, %v", objs, err)
+	}
+	var entry Entry
+	if err := json.Unmarshal([]byte(objs[0].GetValue()), &entry); err != nil {
+		t.Fatalf("error while decoding the entry: %s", err)
+	}
+	if entry.Actor != "someone" || entry.Action != "grant" || entry.Target != "rpc:grant" || entry.RequestID != "some-request" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	if after, _ := entry.After.(map[string]interface{}); after["item"] != "sword" {
+		t.Fatalf("expected the payload as the value after but got %+v", entry.After)
+	}
+
+	// The call took effect even if it could not be recorded
+	failing := rpctest.New(t, &failingStorage{nk})
+	if err := rpc.RegisterRoutes(failing, routes, Middleware(failing.NK)); err != nil {
+		t.Fatalf("error while registering routes: %s", err)
+	}
+	if _, err := failing.Call(ctx, "grant", `{"item":"shield"}`); err != nil {
+		t.Fatalf("expected the call to succeed regardless of the audit log but got %s", err)
+	}
+}
+
+// failingStorage fails every storage write
+type failingStorage struct {
+	*fake.NakamaModule
+}
+
+func (nk *failingStorage) StorageWrite(ctx context.Context, writes []*runtime.StorageWrite) ([]*api.StorageObjectAck, error) {
+	return nil, errors.New("storage is unavailable")
+}
+
+func TestListAndPrune(t *testing.T) {
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error while creating the mock database: %s", err)
+	}
+	defer db.Close()
+
+	init := rpctest.New(t, fake.NewNakamaModule(), auditRoutes...)
+	init.DB = db
+
+	since := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer, _ := json.Marshal(&Entry{ID: "2", Actor: "root", Action: "walletUpdate", Target: "wallet:u"})
+	older, _ := json.Marshal(&Entry{ID: "1", Actor: "root", Action: "walletUpdate", Target: "wallet:u"})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT value FROM storage WHERE collection = $1 AND user_id = $2 AND key < $3 AND key >= $4 AND value->>'actor' = $5 AND value->>'target' LIKE $6 ORDER BY key DESC LIMIT $7")).
+		WithArgs(CollectionID, globalUserID, "3", timeKey(since), "root", `wallet:u\_%`, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow(newer).AddRow(older))
+
+	var resp ListEntries_Response
+	err = init.CallJSON(context.Background(), "audit_list", map[string]interface{}{
+		"actor": "root", "targetPrefix": "wallet:u_", "since": since, "after": "3", "limit": 1,
+	}, &resp)
+	if err != nil {
+		t.Fatalf("error while listing: %s", err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].ID != "2" || resp.Cursor != "2" {
+		t.Fatalf("expected a page with the newer entry but got %+v", resp)
+	}
+
+	if err := init.CallJSON(context.Background(), "audit_list", map[string]interface{}{"limit": 1000}, nil); err == nil {
+		t.Fatalf("expected a limit above %d to be refused", maxListLimit)
+	}
+
+	if err := init.CallJSON(rpctest.WithUserID(context.Background(), "some-user-id", "someone"), "audit_list", map[string]interface{}{}, nil); err == nil {
+		t.Fatalf("expected players to be refused the audit log")
+	}
+
+	before := time.Now()
+	mock.ExpectExec("DELETE FROM storage").
+		WithArgs(CollectionID, globalUserID, timeKey(before)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	if deleted, err := Prune(context.Background(), db, before); err != nil || deleted != 3 {
+		t.Fatalf("expected 3 entries to be pruned but got %d, %v", deleted, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %s", err)
+	}
+}
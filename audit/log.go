@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+const (
+	// DefaultRetention is how long entries are kept for unless told otherwise
+	DefaultRetention = 90 * 24 * time.Hour
+
+	// DefaultPruneInterval is how often entries past their retention are deleted unless told otherwise
+	DefaultPruneInterval = time.Hour
+
+	// globalUserID owns the objects of global storage collections
+	globalUserID = "00000000-0000-0000-0000-000000000000"
+)
+
+// Filter narrows down the entries listed, its zero fields matching every entry
+type Filter struct {
+	Actor        string    `json:"actor"`
+	Action       string    `json:"action"`
+	TargetPrefix string    `json:"targetPrefix"`
+	RequestID    string    `json:"requestId"`
+	Since        time.Time `json:"since"`
+	Until        time.Time `json:"until"`
+}
+
+// List returns up to limit entries matching filter, newest first, starting right after the entry with the id after
+// when it is not empty. Entries are read from Nakama's storage table as the runtime only lists objects oldest first.
+func List(ctx context.Context, db *sql.DB, filter *Filter, after string, limit int) ([]*Entry, error) {
+
+	query := "SELECT value FROM storage WHERE collection = $1 AND user_id = $2"
+	args := []interface{}{CollectionID, globalUserID}
+
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		query += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if after != "" {
+		where("key < $%d", after)
+	}
+	if !filter.Since.IsZero() {
+		where("key >= $%d", timeKey(filter.Since))
+	}
+	if !filter.Until.IsZero() {
+		where("key < $%d", timeKey(filter.Until))
+	}
+	if filter.Actor != "" {
+		where("value->>'actor' = $%d", filter.Actor)
+	}
+	if filter.Action != "" {
+		where("value->>'action' = $%d", filter.Action)
+	}
+	if filter.TargetPrefix != "" {
+		escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+		where("value->>'target' LIKE $%d", escaper.Replace(filter.TargetPrefix)+"%")
+	}
+	if filter.RequestID != "" {
+		where("value->>'requestId' = $%d", filter.RequestID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY key DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*Entry{}
+	for rows.Next() {
+		var value []byte
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(value, entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// Prune deletes the entries recorded before the given time, returning how many were deleted
+func Prune(ctx context.Context, db *sql.DB, before time.Time) (int64, error) {
+
+	result, err := db.ExecContext(ctx,
+		"DELETE FROM storage WHERE collection = $1 AND user_id = $2 AND key < $3",
+		CollectionID, globalUserID, timeKey(before))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// prune deletes the entries older than retention right away and then every interval, until ctx is done
+func prune(ctx context.Context, logger runtime.Logger, db *sql.DB, retention time.Duration, interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := Prune(ctx, db, time.Now().Add(-retention))
+		if err != nil {
+			logger.Error("failed to prune audit entries: %s", err)
+		} else if deleted > 0 {
+			logger.Info("pruned %d audit entries older than %s", deleted, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
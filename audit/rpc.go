@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+var (
+	// auditRoutes only answer server to server calls, as entries hold whatever changes admins made
+	auditRoutes = rpc.WithMiddleware([]rpc.RPCRoute{
+		&rpc.JsonRoute{Name: "audit_list", InputModel: func() interface{} { return new(ListEntries_Request) }, Handler: listEntries},
+	}, rpc.ServerOnly)
+)
+
+// Options configures the retention of audit entries
+type Options struct {
+	// Retention is how long entries are kept for, DefaultRetention when zero and forever when negative
+	Retention time.Duration
+	// PruneInterval is how often entries past their retention are deleted, DefaultPruneInterval when zero
+	PruneInterval time.Duration
+}
+
+type ListEntries_Request struct {
+	Filter
+	After string `json:"after"`
+	Limit int    `json:"limit"`
+}
+
+type ListEntries_Response struct {
+	Entries []*Entry `json:"entries"`
+	// Cursor is passed as `after` to list the next entries, empty when there are none
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// RegisterAudit registers the `audit_list` RPC and deletes entries past their retention until ctx is done
+func RegisterAudit(ctx context.Context, logger runtime.Logger, db *sql.DB, init runtime.Initializer, opts Options) error {
+
+	if err := rpc.RegisterRoutes(init, auditRoutes); err != nil {
+		return err
+	}
+
+	retention, interval := opts.Retention, opts.PruneInterval
+	if retention == 0 {
+		retention = DefaultRetention
+	}
+	if interval == 0 {
+		interval = DefaultPruneInterval
+	}
+	if retention > 0 {
+		go prune(ctx, logger, db, retention, interval)
+	}
+
+	return nil
+}
+
+func listEntries(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
+
+	request := input.(*ListEntries_Request)
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	if limit < 1 || limit > maxListLimit {
+		return nil, fmt.Errorf("`limit` must be between 1 and %d", maxListLimit)
+	}
+
+	// One more entry than asked for tells whether there is a next page
+	entries, err := List(ctx, db, &request.Filter, request.After, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListEntries_Response{Entries: entries}
+	if len(entries) > limit {
+		response.Entries = entries[:limit]
+		response.Cursor = entries[limit-1].ID
+	}
+
+	return response, nil
+}
+
+// Middleware records an entry for every successful call of the RPCs it wraps, with the RPC's name as the action and
+// its payload as the value after, so that admin RPCs are audited like GraphQL mutations.
+// As the call already took effect, failing to record it is logged rather than failing the call:
+//
+//	rpc.RegisterRoutes(initializer, AdminRoutes, audit.Middleware(nk))
+func Middleware(nk runtime.NakamaModule) rpc.Middleware {
+	return func(ctx context.Context, logger runtime.Logger, name string, next func(ctx context.Context) error) error {
+
+		ctx = EnsureRequestID(ctx)
+
+		if err := next(ctx); err != nil {
+			return err
+		}
+
+		var after interface{} = rpc.Payload(ctx)
+		if payload := []byte(rpc.Payload(ctx)); json.Valid(payload) {
+			after = json.RawMessage(payload)
+		}
+
+		if err := Record(ctx, nk, name, "rpc:"+name, nil, after); err != nil {
+			logger.Error("failed to record the call of `%s` in the audit log: %s", name, err)
+		}
+
+		return nil
+	}
+}
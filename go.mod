@@ -1,15 +1,22 @@
 module github.com/mastern2k3/poseidon
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.3.2
 	github.com/coreos/etcd v3.3.11+incompatible // indirect
 	github.com/gobuffalo/events v1.2.0 // indirect
 	github.com/gobuffalo/meta v0.0.0-20190126124307-c8fb6f4eb5a9 // indirect
 	github.com/gobuffalo/packr v1.21.9
 	github.com/gofrs/uuid v3.2.0+incompatible // indirect
 	github.com/golang/mock v1.2.0
+	github.com/golang/protobuf v1.2.0
 	github.com/graphql-go/graphql v0.7.7
 	github.com/heroiclabs/nakama v2.3.2+incompatible
 	github.com/ugorji/go/codec v0.0.0-20190126102652-8fd0f8d918c8 // indirect
+	golang.org/x/crypto v0.0.0-20181203042331-505ab145d0a9
 	google.golang.org/appengine v1.2.0 // indirect
 	google.golang.org/grpc v1.21.1 // indirect
 )
+
+// Plugins must share the versions of the packages they have in common with the Nakama binary loading them,
+// so x/crypto is held at the revision Nakama 2.3.2 vendors (505ab145d0a9) whatever other modules require
+replace golang.org/x/crypto => golang.org/x/crypto v0.0.0-20181203042331-505ab145d0a9
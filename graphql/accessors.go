@@ -0,0 +1,203 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/audit"
+	"github.com/mastern2k3/poseidon/storage"
+)
+
+// RegisterAccessors makes the collections of accessors browsable without hand written types.
+// The model of each accessor is reflected as in RegisterModels, and for a collection named `stats` users get
+// a `stats` field, the root query a `stats(userId:)` field and the root mutation a `setStats(userId:, value:)` field.
+// Must be called before RegisterGraphQL.
+func RegisterAccessors(accessors ...*storage.CollectionAccessor) error {
+	for _, acc := range accessors {
+		if err := registerAccessor(acc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func registerAccessor(acc *storage.CollectionAccessor) error {
+
+	fieldName := collectionFieldName(acc.CollectionID)
+	if fieldName == "" {
+		return fmt.Errorf("collection `%s` has no characters usable in a field name", acc.CollectionID)
+	}
+	mutationName := "set" + strings.ToUpper(fieldName[:1]) + fieldName[1:]
+
+	schemaMutex.Lock()
+	defer schemaMutex.Unlock()
+
+	if _, has := userType.Fields()[fieldName]; has {
+		return fmt.Errorf("users already have a `%s` field", fieldName)
+	}
+
+	// The types of the fields are set once the model is registered, which is only done when the fields do not
+	// conflict with others as a registered model cannot be removed
+	query := &graphql.Field{
+		Description: fmt.Sprintf("Reads a user's object in the `%s` collection.", acc.CollectionID),
+		Args: graphql.FieldConfigArgument{
+			"userId": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return getAccessorModel(p, acc, p.Args["userId"].(string))
+		},
+	}
+	mutation := &graphql.Field{
+		Description: fmt.Sprintf("Replaces a user's object in the `%s` collection, the value must match its model.", acc.CollectionID),
+		Args: graphql.FieldConfigArgument{
+			"userId": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"value": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(jsonType),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return setAccessorModel(p, acc, mutationName)
+		},
+	}
+
+	var modelType *graphql.Object
+
+	return extend(Extension{
+		Module:    "accessor of " + acc.CollectionID,
+		Queries:   graphql.Fields{fieldName: query},
+		Mutations: graphql.Fields{mutationName: mutation},
+	}, func() error {
+		modelsMutex.Lock()
+		defer modelsMutex.Unlock()
+
+		var err error
+		if modelType, err = registerModel(acc); err != nil {
+			return err
+		}
+
+		query.Type = modelType
+		mutation.Type = graphql.NewNonNull(modelType)
+
+		userType.AddFieldConfig(fieldName, &graphql.Field{
+			Type:        modelType,
+			Description: fmt.Sprintf("The user's object in the `%s` collection.", acc.CollectionID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return getAccessorModel(p, acc, p.Source.(*api.User).GetId())
+			},
+		})
+
+		return nil
+	})
+}
+
+// setAccessorModel replaces a user's object in the collection of acc with the value argument, if it was not changed
+// since it was read, writing the audit entry of the change along with it
+func setAccessorModel(p graphql.ResolveParams, acc *storage.CollectionAccessor, action string) (interface{}, error) {
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	userID := p.Args["userId"].(string)
+
+	encoded, err := json.Marshal(p.Args["value"])
+	if err != nil {
+		return nil, err
+	}
+
+	model := acc.NewModel()
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(model); err != nil {
+		return nil, fmt.Errorf("`value` does not match the model of `%s`: %s", acc.CollectionID, err)
+	}
+
+	value, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := readStorageObject(p.Context, nk, acc.CollectionID, acc.KeyID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Written as the accessor saves objects, unless there is one to keep the permissions of
+	write := &runtime.StorageWrite{
+		Collection: acc.CollectionID,
+		Key:        acc.KeyID,
+		UserID:     userID,
+		Value:      string(value),
+		Version:    "*",
+	}
+
+	var before interface{}
+
+	if existing != nil {
+		before = json.RawMessage(existing.GetValue())
+		write.Version = existing.GetVersion()
+		write.PermissionRead = int(existing.GetPermissionRead())
+		write.PermissionWrite = int(existing.GetPermissionWrite())
+	}
+
+	entry, err := audit.NewWrite(p.Context, action, storageTarget(acc.CollectionID, acc.KeyID, userID), before, model)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := nk.StorageWrite(p.Context, []*runtime.StorageWrite{write, entry}); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// getAccessorModel reads a user's object in the collection of acc, batched with the reads of other users,
+// falling back on the accessor's default when there is one
+func getAccessorModel(p graphql.ResolveParams, acc *storage.CollectionAccessor, userID string) (interface{}, error) {
+	thunk := loadStorageObject(p, acc.CollectionID, acc.KeyID, userID)
+	return func() (interface{}, error) {
+		loaded, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		obj := storageObjectValue(loaded)
+		if obj == nil {
+			if acc.DefaultFactory == nil {
+				return nil, nil
+			}
+			return acc.DefaultFactory(), nil
+		}
+		model := acc.NewModel()
+		if err := json.Unmarshal([]byte(obj.GetValue()), model); err != nil {
+			return nil, err
+		}
+		return model, nil
+	}, nil
+}
+
+// collectionFieldName turns a collection id such as `match-stats` into a field name such as `matchStats`
+func collectionFieldName(collection string) string {
+	words := strings.FieldsFunc(collection, func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	name := ""
+	for i, word := range words {
+		if i > 0 {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		name += word
+	}
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,179 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/audit"
+)
+
+var (
+	auditEntryType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "AuditEntry",
+		Description: "A change made through an admin api.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*audit.Entry).ID, nil
+				},
+			},
+			"actor": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The admin or user who made the change.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*audit.Entry).Actor, nil
+				},
+			},
+			"action": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The mutation or RPC which made the change.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*audit.Entry).Action, nil
+				},
+			},
+			"target": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "What was changed, such as `storage:<collection>/<key>/<userId>` or `liveparam:<name>`.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*audit.Entry).Target, nil
+				},
+			},
+			"before": &graphql.Field{
+				Type:        jsonType,
+				Description: "The value before the change, null if there was none.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*audit.Entry).Before, nil
+				},
+			},
+			"after": &graphql.Field{
+				Type:        jsonType,
+				Description: "The value after the change, null if there is none.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*audit.Entry).After, nil
+				},
+			},
+			"time": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*audit.Entry).Time, nil
+				},
+			},
+			"requestId": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The request the change was made in, shared by the changes made together.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalString(p.Source.(*audit.Entry).RequestID), nil
+				},
+			},
+		},
+	})
+
+	auditEntryConnectionType = newConnectionType(auditEntryType, "A page of audit entries.")
+
+	auditLogField = &graphql.Field{
+		Type:        graphql.NewNonNull(auditEntryConnectionType),
+		Description: "The changes made through admin apis, newest first.",
+		Args: connectionArgs(graphql.FieldConfigArgument{
+			"actor": &graphql.ArgumentConfig{
+				Type:        graphql.String,
+				Description: "Only return changes made by this admin or user.",
+			},
+			"action": &graphql.ArgumentConfig{
+				Type:        graphql.String,
+				Description: "Only return changes made by this mutation or RPC.",
+			},
+			"targetPrefix": &graphql.ArgumentConfig{
+				Type:        graphql.String,
+				Description: "Only return changes to targets starting with this prefix, such as `wallet:<userId>`.",
+			},
+			"requestId": &graphql.ArgumentConfig{
+				Type:        graphql.String,
+				Description: "Only return changes made in this request.",
+			},
+			"since": &graphql.ArgumentConfig{
+				Type:        graphql.DateTime,
+				Description: "Only return changes made at or after this time.",
+			},
+			"until": &graphql.ArgumentConfig{
+				Type:        graphql.DateTime,
+				Description: "Only return changes made before this time.",
+			},
+		}),
+		Resolve: resolveAuditLog,
+	}
+)
+
+func resolveAuditLog(p graphql.ResolveParams) (interface{}, error) {
+
+	db, err := dbFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	first, after, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if after != "" {
+		bytes, err := base64.RawURLEncoding.DecodeString(after)
+		if err != nil {
+			return nil, fmt.Errorf("malformed cursor `%s`", after)
+		}
+		after = string(bytes)
+	}
+
+	filter := &audit.Filter{
+		Since: timeArg(p, "since"),
+		Until: timeArg(p, "until"),
+	}
+	filter.Actor, _ = p.Args["actor"].(string)
+	filter.Action, _ = p.Args["action"].(string)
+	filter.TargetPrefix, _ = p.Args["targetPrefix"].(string)
+	filter.RequestID, _ = p.Args["requestId"].(string)
+
+	entries, err := audit.List(p.Context, db, filter, after, first+1)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &connection{}
+	for _, entry := range entries {
+		if len(conn.edges) == first {
+			conn.hasNextPage = true
+			break
+		}
+		conn.edges = append(conn.edges, edge{base64.RawURLEncoding.EncodeToString([]byte(entry.ID)), entry})
+	}
+
+	return conn, nil
+}
+
+func timeArg(p graphql.ResolveParams, name string) time.Time {
+	if t, ok := p.Args[name].(time.Time); ok {
+		return t
+	}
+	if t, ok := p.Args[name].(*time.Time); ok && t != nil {
+		return *t
+	}
+	return time.Time{}
+}
+
+// recordApplied records a change already applied through the NakamaModule, which cannot store the audit entry along
+// with it. Failing to record the change does not undo it, so the failure is logged rather than returned, as an error
+// would have clients take the change for failed and make it again.
+func recordApplied(p graphql.ResolveParams, nk runtime.NakamaModule, action string, target string, before interface{}, after interface{}) {
+	if err := audit.Record(p.Context, nk, action, target, before, after); err != nil {
+		if logger, ok := p.Context.Value(GRAPHQL_CTX_LOGGER).(runtime.Logger); ok {
+			logger.Error("failed to record `%s` of `%s` in the audit log: %s", action, target, err)
+		} else {
+			log.Printf("failed to record `%s` of `%s` in the audit log: %s", action, target, err)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+type edge struct {
+	cursor string
+	node   interface{}
+}
+
+// connection is a page of items, exposed as a Relay-style connection
+type connection struct {
+	edges       []edge
+	hasNextPage bool
+	// endCursor overrides the cursor of the last edge, for pages which stop short of filling up
+	endCursor string
+}
+
+var (
+	pageInfoType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PageInfo",
+		Description: "Information about a page of a connection.",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether there are more items after this page.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*connection).hasNextPage, nil
+				},
+			},
+			"hasPreviousPage": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether there are items before this page, always false as only forward paging is supported.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return false, nil
+				},
+			},
+			"startCursor": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The cursor of the first item in this page.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					edges := p.Source.(*connection).edges
+					if len(edges) == 0 {
+						return nil, nil
+					}
+					return edges[0].cursor, nil
+				},
+			},
+			"endCursor": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The cursor of the last item in this page, used as `after` to fetch the next page.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					conn := p.Source.(*connection)
+					if conn.endCursor != "" {
+						return conn.endCursor, nil
+					}
+					edges := conn.edges
+					if len(edges) == 0 {
+						return nil, nil
+					}
+					return edges[len(edges)-1].cursor, nil
+				},
+			},
+		},
+	})
+)
+
+// connectionArgs returns the paging arguments of a connection field along with args
+func connectionArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	return withArgs(graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: defaultPageSize,
+			Description:  fmt.Sprintf("The number of items to return, up to %d.", maxPageSize),
+		},
+		"after": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "The cursor after which to start returning items.",
+		},
+	}, args)
+}
+
+// pageArgs reads the paging arguments of a connection field
+func pageArgs(p graphql.ResolveParams) (first int, after string, err error) {
+	first, _ = p.Args["first"].(int)
+	if first < 1 || first > maxPageSize {
+		return 0, "", fmt.Errorf("`first` must be between 1 and %d", maxPageSize)
+	}
+	after, _ = p.Args["after"].(string)
+	return first, after, nil
+}
+
+// newConnectionType creates the connection and edge types paging over nodes of nodeType
+func newConnectionType(nodeType *graphql.Object, description string) *graphql.Object {
+
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        nodeType.Name() + "Edge",
+		Description: fmt.Sprintf("A %s within a connection.", nodeType.Name()),
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The cursor pointing right after this item.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(edge).cursor, nil
+				},
+			},
+			"node": &graphql.Field{
+				Type:        graphql.NewNonNull(nodeType),
+				Description: "The item.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(edge).node, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        nodeType.Name() + "Connection",
+		Description: description,
+		Fields: graphql.Fields{
+			"edges": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType))),
+				Description: "The items in this page.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*connection).edges, nil
+				},
+			},
+			"pageInfo": &graphql.Field{
+				Type:        graphql.NewNonNull(pageInfoType),
+				Description: "Information about this page.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+}
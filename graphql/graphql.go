@@ -4,12 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"time"
 
 	"github.com/graphql-go/graphql"
-	"github.com/heroiclabs/nakama/api"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
 	"github.com/heroiclabs/nakama/runtime"
 
+	"github.com/mastern2k3/poseidon/audit"
 	"github.com/mastern2k3/poseidon/rpc"
 )
 
@@ -17,225 +21,11 @@ type ContextKey string
 
 const (
 	GRAPHQL_CTX_NAKAMA_MODULE ContextKey = "nakama_module"
+	GRAPHQL_CTX_DB            ContextKey = "db"
+	GRAPHQL_CTX_LOGGER        ContextKey = "logger"
 )
 
-type ledgerItemChangesetItem = struct {
-	key   string
-	value float64
-}
-
-type ledgerItemMetadataItem = struct {
-	key   string
-	value string
-}
-
 var (
-	ledgerItemChangesetItemType = graphql.NewObject(graphql.ObjectConfig{
-		Name: "LedgerItemChangesetItem",
-		// Description: "A storage object persisted on Nakama.",
-		Fields: graphql.Fields{
-			"key": &graphql.Field{
-				Type: graphql.NewNonNull(graphql.String),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(ledgerItemChangesetItem).key, nil
-				},
-			},
-			"value": &graphql.Field{
-				Type: graphql.NewNonNull(graphql.Float),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(ledgerItemChangesetItem).value, nil
-				},
-			},
-		},
-	})
-
-	ledgerItemMetadataItemType = graphql.NewObject(graphql.ObjectConfig{
-		Name: "LedgerItemMetadataItem",
-		// Description: "A storage object persisted on Nakama.",
-		Fields: graphql.Fields{
-			"key": &graphql.Field{
-				Type: graphql.NewNonNull(graphql.String),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(ledgerItemChangesetItem).key, nil
-				},
-			},
-			"value": &graphql.Field{
-				Type: graphql.NewNonNull(graphql.String),
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(ledgerItemChangesetItem).value, nil
-				},
-			},
-		},
-	})
-
-	ledgerItemType = graphql.NewObject(graphql.ObjectConfig{
-		Name: "LedgerItem",
-		// Description: "A storage object persisted on Nakama.",
-		Fields: graphql.Fields{
-			"id": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.String),
-				Description: "The ledger item Id.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(runtime.WalletLedgerItem).GetID(), nil
-				},
-			},
-			"createTime": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.DateTime),
-				Description: "The ledger item creation time.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return time.Unix(p.Source.(runtime.WalletLedgerItem).GetCreateTime(), 0), nil
-				},
-			},
-			"updateTime": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.DateTime),
-				Description: "The ledger update time.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return time.Unix(p.Source.(runtime.WalletLedgerItem).GetUpdateTime(), 0), nil
-				},
-			},
-			"changeset": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(ledgerItemChangesetItemType))),
-				Description: "The ledger item changeset.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					changeset := []ledgerItemChangesetItem{}
-					for k, v := range p.Source.(runtime.WalletLedgerItem).GetChangeset() {
-						changeset = append(changeset, ledgerItemChangesetItem{k, v.(float64)})
-					}
-					return changeset, nil
-				},
-			},
-			"metadata": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(ledgerItemMetadataItemType))),
-				Description: "The ledger item metadata.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					metadata := []ledgerItemMetadataItem{}
-					for k, v := range p.Source.(runtime.WalletLedgerItem).GetMetadata() {
-						metadata = append(metadata, ledgerItemMetadataItem{k, v.(string)})
-					}
-					return metadata, nil
-				},
-			},
-			// "user": &graphql.Field{
-			// 	Type:        graphql.NewNonNull(graphql.String),
-			// 	Description: "The ledger item Id.",
-			// 	Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-			// 		return p.Source.(runtime.WalletLedgerItem).GetUserID(), nil
-			// 	},
-			// },
-		},
-	})
-
-	storageType = graphql.NewObject(graphql.ObjectConfig{
-		Name:        "StorageObject",
-		Description: "A storage object persisted on Nakama.",
-		Fields: graphql.Fields{
-			"key": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.String),
-				Description: "The key defining the stored object.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(*api.StorageObject).GetKey(), nil
-				},
-			},
-			"value": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.String),
-				Description: "The value stored in the object.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(*api.StorageObject).GetValue(), nil
-				},
-			},
-		},
-	})
-
-	accountType = graphql.NewObject(graphql.ObjectConfig{
-		Name:        "Account",
-		Description: "A registered Nakama user account.",
-		Fields: graphql.Fields{
-			"customId": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.String),
-				Description: "The custom id of the account.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(*api.Account).GetCustomId(), nil
-				},
-			},
-			"email": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.String),
-				Description: "The email of the account.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(*api.Account).GetEmail(), nil
-				},
-			},
-			"wallet": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.String),
-				Description: "The wallet of the account.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(*api.Account).GetWallet(), nil
-				},
-			},
-		},
-	})
-
-	userType = graphql.NewObject(graphql.ObjectConfig{
-		Name:        "User",
-		Description: "A registered Nakama user.",
-		Fields: graphql.Fields{
-			"id": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.String),
-				Description: "The id of the user.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(*api.User).GetId(), nil
-				},
-			},
-			"username": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.String),
-				Description: "The username of the user.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					return p.Source.(*api.User).GetUsername(), nil
-				},
-			},
-			"account": &graphql.Field{
-				Type:        graphql.NewNonNull(accountType),
-				Description: "The account of the user.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
-					acc, err := nk.AccountGetId(p.Context, p.Source.(*api.User).GetId())
-					if err != nil {
-						return nil, err
-					}
-					return acc, nil
-				},
-			},
-			"storage": &graphql.Field{
-				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(storageType))),
-				Args: graphql.FieldConfigArgument{
-					"collection": &graphql.ArgumentConfig{
-						Type: graphql.NewNonNull(graphql.String),
-					},
-				},
-				Description: "The account of the user.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
-					objs, _, err := nk.StorageList(p.Context, p.Source.(*api.User).GetId(), p.Args["collection"].(string), 10, "")
-					if err != nil {
-						return nil, err
-					}
-					return objs, nil
-				},
-			},
-			"ledger": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(ledgerItemType))),
-				Description: "The user's wallet transaction ledger.",
-				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
-					items, err := nk.WalletLedgerList(p.Context, p.Source.(*api.User).GetId())
-					if err != nil {
-						return nil, err
-					}
-					return items, nil
-				},
-			},
-		},
-	})
-
 	rootQuery = graphql.NewObject(graphql.ObjectConfig{
 		Name: "RootQuery",
 		Fields: graphql.Fields{
@@ -259,21 +49,25 @@ var (
 					return users[0], nil
 				},
 			},
+			"userById":                      userByIdField,
+			"usersById":                     usersByIdField,
+			"userByCustomId":                userByCustomIdField,
+			"userByDeviceId":                userByDeviceIdField,
+			"userByEmail":                   userByEmailField,
+			"searchUsers":                   searchUsersField,
+			"group":                         groupField,
+			"leaderboardRecords":            leaderboardRecordsField,
+			"leaderboardRecordsAroundOwner": leaderboardRecordsAroundOwnerField,
+			"tournaments":                   tournamentsField,
+			"matches":                       matchesField,
+			"streamPresences":               streamPresencesField,
+			"auditLog":                      auditLogField,
 			"globalStorage": &graphql.Field{
-				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(storageType))),
-				Args: graphql.FieldConfigArgument{
-					"collection": &graphql.ArgumentConfig{
-						Type: graphql.NewNonNull(graphql.String),
-					},
-				},
-				Description: "The account of the user.",
+				Type:        graphql.NewNonNull(storageConnectionType),
+				Args:        storageConnectionArgs,
+				Description: "The storage objects owned by no user in a collection.",
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
-					objs, _, err := nk.StorageList(p.Context, "", p.Args["collection"].(string), 10, "")
-					if err != nil {
-						return nil, err
-					}
-					return objs, nil
+					return resolveStorageConnection(p, "")
 				},
 			},
 		},
@@ -302,50 +96,190 @@ var (
 					return users[0], nil
 				},
 			},
+			"writeStorage":            writeStorageField,
+			"patchStorage":            patchStorageField,
+			"deleteStorage":           deleteStorageField,
+			"addFriend":               addFriendField,
+			"removeFriend":            removeFriendField,
+			"addGroupMember":          addGroupMemberField,
+			"removeGroupMember":       removeGroupMemberField,
+			"createLeaderboard":       createLeaderboardField,
+			"createTournament":        createTournamentField,
+			"deleteLeaderboardRecord": deleteLeaderboardRecordField,
+			"correctLeaderboardScore": correctLeaderboardScoreField,
+			"correctTournamentScore":  correctTournamentScoreField,
+			"walletUpdate":            walletUpdateField,
+			"kickFromStream":          kickFromStreamField,
+			"sendToStream":            sendToStreamField,
+			"sendNotification":        sendNotificationField,
+			"sendGroupNotification":   sendGroupNotificationField,
 		},
 	})
 
-	schemaConfig = graphql.SchemaConfig{Query: rootQuery, Mutation: rootMutation}
-	schema       graphql.Schema
+	schema graphql.Schema
 )
 
 var (
-	graphQLRoutes = []rpc.RPCRoute{
-		&rpc.JsonRoute{"graphql", func() interface{} { return new(GraphQLRequest) }, query},
-	}
+	// graphQLRoutes only answer server to server calls, as the schema exposes every player's data and admin mutations
+	graphQLRoutes = rpc.WithMiddleware([]rpc.RPCRoute{
+		&rpc.JsonRoute{Name: "graphql", InputModel: func() interface{} { return new(GraphQLRequest) }, Handler: query},
+	}, rpc.ServerOnly)
 )
 
+// ConfigureRootQuery adds the fields conf adds to the object it is given to the root query, as an extension.
+//
+// Deprecated: use Extend, which names the module adding the fields in conflict errors.
 func ConfigureRootQuery(conf func(rootQuery *graphql.Object) error) error {
-	return conf(rootQuery)
+	return configureRoot(rootQuery.Name(), conf, func(fields graphql.Fields) Extension {
+		return Extension{Module: "ConfigureRootQuery", Queries: fields}
+	})
 }
 
+// ConfigureRootMutation adds the fields conf adds to the object it is given to the root mutation, as an extension.
+//
+// Deprecated: use Extend, which names the module adding the fields in conflict errors.
 func ConfigureRootMutation(conf func(rootMutation *graphql.Object) error) error {
-	return conf(rootMutation)
+	return configureRoot(rootMutation.Name(), conf, func(fields graphql.Fields) Extension {
+		return Extension{Module: "ConfigureRootMutation", Mutations: fields}
+	})
 }
 
+func configureRoot(name string, conf func(root *graphql.Object) error, extension func(fields graphql.Fields) Extension) error {
+	if err := extensible(); err != nil {
+		return err
+	}
+	root := graphql.NewObject(graphql.ObjectConfig{Name: name, Fields: graphql.Fields{}})
+	if err := conf(root); err != nil {
+		return err
+	}
+	if err := root.Error(); err != nil {
+		return err
+	}
+	return Extend(extension(fieldsOf(root)))
+}
+
+// RegisterGraphQL builds the schema out of the built-in fields and every extension registered so far, after which
+// the schema can no longer be extended, and registers the graphql RPC
 func RegisterGraphQL(init runtime.Initializer) error {
-	var err error
-	schema, err = graphql.NewSchema(schemaConfig)
-	if err != nil {
+	if err := buildSchema(); err != nil {
 		return err
 	}
 	return rpc.RegisterRoutes(init, graphQLRoutes)
 }
 
 type GraphQLRequest struct {
-	Query string `json:"query"`
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+	Extensions    *RequestExtensions     `json:"extensions,omitempty"`
 }
 
-func query(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, request interface{}) (interface{}, error) {
-	query := request.(*GraphQLRequest)
-	logger.Info("query: %+v %s", query, runtime.RUNTIME_CTX_MATCH_NODE)
-	newCtx := context.WithValue(ctx, GRAPHQL_CTX_NAKAMA_MODULE, nk)
-	params := graphql.Params{
+// GraphQLResponse is a spec compliant GraphQL response, errors are returned within it and not as RPC errors
+type GraphQLResponse struct {
+	Data   interface{}    `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+type GraphQLError struct {
+	Message    string                    `json:"message"`
+	Locations  []location.SourceLocation `json:"locations,omitempty"`
+	Path       []interface{}             `json:"path,omitempty"`
+	Extensions map[string]interface{}    `json:"extensions,omitempty"`
+}
+
+// Execute parses, validates and executes a GraphQL request against the registered schema
+func Execute(ctx context.Context, nk runtime.NakamaModule, request *GraphQLRequest) *GraphQLResponse {
+
+	doc, failed := parse(request)
+	if failed != nil {
+		return failed
+	}
+
+	return execute(ctx, nk, doc, request, nil)
+}
+
+// ExecuteQuery executes a request like Execute, unless it is a mutation or a subscription,
+// for transports which must not change anything such as HTTP GET requests
+func ExecuteQuery(ctx context.Context, nk runtime.NakamaModule, request *GraphQLRequest) *GraphQLResponse {
+
+	doc, failed := parse(request)
+	if failed != nil {
+		return failed
+	}
+
+	if op := operation(doc, request.OperationName); op != nil && op.Operation != ast.OperationTypeQuery {
+		return newResponse(nil, gqlerrors.FormatErrors(fmt.Errorf("%s operations cannot be executed here", op.Operation)))
+	}
+
+	return execute(ctx, nk, doc, request, nil)
+}
+
+// parse parses and validates a request, returning a response holding the errors if it is invalid
+func parse(request *GraphQLRequest) (*ast.Document, *GraphQLResponse) {
+
+	if failed := resolvePersistedQuery(request); failed != nil {
+		return nil, failed
+	}
+
+	src := source.NewSource(&source.Source{
+		Body: []byte(request.Query),
+		Name: "GraphQL request",
+	})
+
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+
+	if err != nil {
+		return nil, newResponse(nil, gqlerrors.FormatErrors(err))
+	}
+
+	if validation := graphql.ValidateDocument(&schema, doc, nil); !validation.IsValid {
+		return nil, newResponse(nil, validation.Errors)
+	}
+
+	if err := checkLimits(Limits, &schema, doc, request.OperationName, request.Variables); err != nil {
+		return nil, newResponse(nil, gqlerrors.FormatErrors(err))
+	}
+
+	return doc, nil
+}
+
+func execute(ctx context.Context, nk runtime.NakamaModule, doc *ast.Document, request *GraphQLRequest, root interface{}) *GraphQLResponse {
+
+	if err := checkMutations(ctx, doc, operation(doc, request.OperationName)); err != nil {
+		return newResponse(nil, gqlerrors.FormatErrors(err))
+	}
+
+	r := graphql.Execute(graphql.ExecuteParams{
 		Schema:        schema,
-		RequestString: query.Query,
-		Context:       newCtx,
+		Root:          root,
+		AST:           doc,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       context.WithValue(context.WithValue(ctx, GRAPHQL_CTX_NAKAMA_MODULE, nk), GRAPHQL_CTX_LOADERS, newLoaders(nk, !isMutation(doc, request.OperationName))),
+	})
+
+	return newResponse(r.Data, r.Errors)
+}
+
+func newResponse(data interface{}, errs []gqlerrors.FormattedError) *GraphQLResponse {
+	resp := &GraphQLResponse{Data: data}
+	for _, err := range errs {
+		resp.Errors = append(resp.Errors, GraphQLError{
+			Message:    err.Message,
+			Locations:  err.Locations,
+			Path:       err.Path,
+			Extensions: err.Extensions,
+		})
 	}
-	r := graphql.Do(params)
+	return resp
+}
+
+func query(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, request interface{}) (interface{}, error) {
+	query := request.(*GraphQLRequest)
+	logger.Debug("graphql operation `%s`: %s", query.OperationName, query.Query)
+	// Changes made by the operation share a request id in the audit log
+	ctx = audit.EnsureRequestID(context.WithValue(context.WithValue(ctx, GRAPHQL_CTX_DB, db), GRAPHQL_CTX_LOGGER, logger))
+	r := Execute(ctx, nk, query)
 	if len(r.Errors) > 0 {
 		logger.Error("failed to execute graphql operation, errors: %+v", r.Errors)
 	}
@@ -0,0 +1,1173 @@
+package graphql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/audit"
+	"github.com/mastern2k3/poseidon/rpc/rpctest"
+	"github.com/mastern2k3/poseidon/storage"
+	"github.com/mastern2k3/poseidon/tests/fake"
+)
+
+func executeTest(t *testing.T, nk *fake.NakamaModule, request *GraphQLRequest) *GraphQLResponse {
+	return executeTestDB(t, nk, nil, request)
+}
+
+func executeTestDB(t *testing.T, nk *fake.NakamaModule, db *sql.DB, request *GraphQLRequest) *GraphQLResponse {
+	init := rpctest.New(t, nk)
+	init.DB = db
+	if !init.Registered("graphql") {
+		if err := RegisterGraphQL(init); err != nil {
+			t.Fatalf("error while registering graphql: %s", err)
+		}
+	}
+	var resp GraphQLResponse
+	if err := init.CallJSON(context.Background(), "graphql", request, &resp); err != nil {
+		t.Fatalf("error while calling graphql: %s", err)
+	}
+	return &resp
+}
+
+// thawSchema lets a test extend the schema after earlier tests built it, the next registration rebuilds it
+func thawSchema() {
+	schemaMutex.Lock()
+	defer schemaMutex.Unlock()
+	schemaFrozen = false
+}
+
+func TestVariablesAndOperationName(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	userID := nk.AddUser("someone")
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query: `
+			query ById($username: String!) { userByUsername(username: $username) { id } }
+			query Other { userByUsername(username: "nobody") { id } }
+		`,
+		Variables:     map[string]interface{}{"username": "someone"},
+		OperationName: "ById",
+	})
+
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	user := resp.Data.(map[string]interface{})["userByUsername"].(map[string]interface{})
+	if user["id"] != userID {
+		t.Fatalf("expected user id `%s` but got %+v", userID, user)
+	}
+}
+
+func TestErrorShape(t *testing.T) {
+
+	resp := executeTest(t, fake.NewNakamaModule(), &GraphQLRequest{
+		Query: `{ userByUsername(username: "nobody") { id } }`,
+	})
+
+	if len(resp.Errors) != 1 {
+		t.Fatalf("expected a single error but got %+v", resp.Errors)
+	}
+	err := resp.Errors[0]
+	if len(err.Path) != 1 || err.Path[0] != "userByUsername" {
+		t.Fatalf("expected error path [userByUsername] but got %+v", err.Path)
+	}
+	if len(err.Locations) != 1 || err.Locations[0].Line != 1 {
+		t.Fatalf("expected error location on line 1 but got %+v", err.Locations)
+	}
+}
+
+func TestQueryLimits(t *testing.T) {
+
+	defer func(limits QueryLimits) { Limits = limits }(Limits)
+	Limits = QueryLimits{MaxDepth: 2, MaxComplexity: 8}
+
+	resp := executeTest(t, fake.NewNakamaModule(), &GraphQLRequest{
+		Query: `{ userByUsername(username: "someone") { account { email } } }`,
+	})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "depth") {
+		t.Fatalf("expected a depth error but got %+v", resp.Errors)
+	}
+
+	resp = executeTest(t, fake.NewNakamaModule(), &GraphQLRequest{
+		Query: `{ a: userByUsername(username: "a") { id } b: userByUsername(username: "b") { id } c: userByUsername(username: "c") { id username } d: userByUsername(username: "d") { id username } }`,
+	})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "complexity") {
+		t.Fatalf("expected a complexity error but got %+v", resp.Errors)
+	}
+}
+
+func TestQueryComplexity(t *testing.T) {
+
+	// Registering builds the schema the operations are measured against
+	executeTest(t, fake.NewNakamaModule(), &GraphQLRequest{Query: `{ __typename }`})
+
+	for _, test := range []struct {
+		query      string
+		variables  map[string]interface{}
+		complexity int
+	}{
+		// The edges of a connection are bounded by its `first` argument, defaulting to 10
+		{`{ globalStorage(collection: "c") { edges { node { key } } } }`, nil, 1 + 3*defaultPageSize},
+		{`{ globalStorage(collection: "c", first: 2) { edges { node { key } } } }`, nil, 1 + 3*2},
+		{`query ($first: Int = 5) { globalStorage(collection: "c", first: $first) { edges { node { key } } } }`, nil, 1 + 3*5},
+		{`query ($first: Int = 5) { globalStorage(collection: "c", first: $first) { edges { node { key } } } }`, map[string]interface{}{"first": float64(3)}, 1 + 3*3},
+		{`query ($first: Int) { globalStorage(collection: "c", first: $first) { edges { node { key } } } }`, nil, 1 + 3*defaultPageSize},
+		// Lists of the items given are bounded by the items, other lists take a fixed size
+		{`{ usersById(ids: ["a", "b"]) { id } }`, nil, 1 + 2},
+		{`query ($ids: [String!]!) { usersById(ids: $ids) { id } }`, map[string]interface{}{"ids": []interface{}{"a", "b", "c"}}, 1 + 3},
+		{`{ matches(limit: 2) { presences { userId } } }`, nil, 1 + 2*(1+unboundedListSize)},
+		{`{ matches { ...presences } } fragment presences on Match { presences { userId } }`, nil, 1 + defaultPageSize*(1+unboundedListSize)},
+	} {
+		doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(test.query)})})
+		if err != nil {
+			t.Fatalf("error while parsing %s: %s", test.query, err)
+		}
+		if validation := graphql.ValidateDocument(&schema, doc, nil); !validation.IsValid {
+			t.Fatalf("expected %s to be valid but got %+v", test.query, validation.Errors)
+		}
+		if _, _, complexity := measureOperation(&schema, doc, "", test.variables); complexity != test.complexity {
+			t.Fatalf("expected %s to cost %d but got %d", test.query, test.complexity, complexity)
+		}
+	}
+}
+
+func TestStoragePagination(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	writes := []*runtime.StorageWrite{}
+	for _, key := range []string{"item_a", "item_b", "other", "item_c", "item_d"} {
+		writes = append(writes, &runtime.StorageWrite{Collection: "things", Key: key, Value: "{}"})
+	}
+	if _, err := nk.StorageWrite(context.Background(), writes); err != nil {
+		t.Fatalf("error while writing storage: %s", err)
+	}
+
+	keys := []string{}
+	after := ""
+
+	for page := 0; page < 3; page++ {
+		resp := executeTest(t, nk, &GraphQLRequest{
+			Query:     `query ($after: String) { globalStorage(collection: "things", first: 2, after: $after, keyPrefix: "item_") { edges { node { key } } pageInfo { hasNextPage endCursor } } }`,
+			Variables: map[string]interface{}{"after": after},
+		})
+		if len(resp.Errors) > 0 {
+			t.Fatalf("unexpected errors %+v", resp.Errors)
+		}
+		conn := resp.Data.(map[string]interface{})["globalStorage"].(map[string]interface{})
+		for _, edge := range conn["edges"].([]interface{}) {
+			keys = append(keys, edge.(map[string]interface{})["node"].(map[string]interface{})["key"].(string))
+		}
+		pageInfo := conn["pageInfo"].(map[string]interface{})
+		if !pageInfo["hasNextPage"].(bool) {
+			break
+		}
+		after = pageInfo["endCursor"].(string)
+	}
+
+	if strings.Join(keys, ",") != "item_a,item_b,item_c,item_d" {
+		t.Fatalf("expected all prefixed keys across pages but got %v", keys)
+	}
+}
+
+func TestStoragePrefixScan(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	writes := []*runtime.StorageWrite{}
+	for i := 0; i < 2*maxScannedPages+5; i++ {
+		writes = append(writes, &runtime.StorageWrite{Collection: "things", Key: fmt.Sprintf("other_%03d", i), Value: "{}"})
+	}
+	writes = append(writes, &runtime.StorageWrite{Collection: "things", Key: "zz_item", Value: "{}"})
+	if _, err := nk.StorageWrite(context.Background(), writes); err != nil {
+		t.Fatalf("error while writing storage: %s", err)
+	}
+
+	after := ""
+	for request := 1; ; request++ {
+		resp := executeTest(t, nk, &GraphQLRequest{
+			Query:     `query ($after: String) { globalStorage(collection: "things", first: 1, after: $after, keyPrefix: "zz_") { edges { node { key } } pageInfo { hasNextPage endCursor } } }`,
+			Variables: map[string]interface{}{"after": after},
+		})
+		if len(resp.Errors) > 0 {
+			t.Fatalf("unexpected errors %+v", resp.Errors)
+		}
+		conn := resp.Data.(map[string]interface{})["globalStorage"].(map[string]interface{})
+		pageInfo := conn["pageInfo"].(map[string]interface{})
+
+		if edges := conn["edges"].([]interface{}); len(edges) > 0 {
+			if key := edges[0].(map[string]interface{})["node"].(map[string]interface{})["key"]; key != "zz_item" || request < 2 {
+				t.Fatalf("expected zz_item to be found past the first request but got %v in request %d", key, request)
+			}
+			return
+		}
+
+		if !pageInfo["hasNextPage"].(bool) || pageInfo["endCursor"] == nil {
+			t.Fatalf("expected an empty page to continue the scan but got %+v", pageInfo)
+		}
+		if request > 3 {
+			t.Fatalf("expected the scan to reach zz_item within 3 requests")
+		}
+		after = pageInfo["endCursor"].(string)
+	}
+}
+
+func TestStorageMutations(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	userID := nk.AddUser("someone")
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($userId: String) { writeStorage(collection: "stats", key: "main", userId: $userId, value: "{\"a\":1,\"b\":2}") { version } }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	version := resp.Data.(map[string]interface{})["writeStorage"].(map[string]interface{})["version"].(string)
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($userId: String) { patchStorage(collection: "stats", key: "main", userId: $userId, version: "stale", patch: "{\"a\":null}") { value } }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) != 1 {
+		t.Fatalf("expected a version error but got %+v", resp.Errors)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($userId: String, $version: String) { patchStorage(collection: "stats", key: "main", userId: $userId, version: $version, patch: "{\"a\":null,\"c\":3}") { value } }`,
+		Variables: map[string]interface{}{"userId": userID, "version": version},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	if value := resp.Data.(map[string]interface{})["patchStorage"].(map[string]interface{})["value"]; value != `{"b":2,"c":3}` {
+		t.Fatalf("expected the patched value but got %v", value)
+	}
+
+	entries, _, err := nk.StorageList(context.Background(), "", audit.CollectionID, 10, "")
+	if err != nil {
+		t.Fatalf("error while listing audit entries: %s", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected an audit entry per change but got %d", len(entries))
+	}
+
+	objs, err := nk.StorageRead(context.Background(), []*runtime.StorageRead{{Collection: "stats", Key: "main", UserID: userID}})
+	if err != nil || len(objs) != 1 {
+		t.Fatalf("expected the patched object but got %+v, %v", objs, err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error while creating the mock database: %s", err)
+	}
+	defer db.Close()
+
+	// A delete without a version only deletes the version read, recording the entry in the same transaction
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM storage").
+		WithArgs("stats", "main", userID, "stale").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM storage").
+		WithArgs("stats", "main", userID, objs[0].GetVersion()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO storage").
+		WithArgs(audit.CollectionID, sqlmock.AnyArg(), systemUserID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	resp = executeTestDB(t, nk, db, &GraphQLRequest{
+		Query:     `mutation ($userId: String) { deleteStorage(collection: "stats", key: "main", userId: $userId, version: "stale") }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "no longer at version") {
+		t.Fatalf("expected a version error but got %+v", resp.Errors)
+	}
+
+	resp = executeTestDB(t, nk, db, &GraphQLRequest{
+		Query:     `mutation ($userId: String) { deleteStorage(collection: "stats", key: "main", userId: $userId) }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) > 0 || resp.Data.(map[string]interface{})["deleteStorage"] != true {
+		t.Fatalf("expected the object to be deleted but got %+v", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %s", err)
+	}
+
+	for _, collection := range []string{audit.CollectionID, "admin_users"} {
+		resp = executeTest(t, nk, &GraphQLRequest{
+			Query:     `mutation ($collection: String!) { writeStorage(collection: $collection, key: "root", value: "{}") { version } }`,
+			Variables: map[string]interface{}{"collection": collection},
+		})
+		if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "reserved") {
+			t.Fatalf("expected writing to %s to be refused but got %+v", collection, resp.Errors)
+		}
+	}
+}
+
+func TestServerOnly(t *testing.T) {
+
+	init := rpctest.New(t, fake.NewNakamaModule())
+	if err := RegisterGraphQL(init); err != nil {
+		t.Fatalf("error while registering graphql: %s", err)
+	}
+
+	ctx := rpctest.WithUserID(context.Background(), "some-user-id", "someone")
+	if err := init.CallJSON(ctx, "graphql", &GraphQLRequest{Query: `{ __typename }`}, nil); err == nil {
+		t.Fatalf("expected a call on behalf of a user to be refused")
+	}
+}
+
+func TestUserLookups(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	first, second := nk.AddUser("first"), nk.AddUser("second")
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error while creating the mock database: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id FROM users WHERE custom_id").
+		WithArgs("custom").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(second))
+
+	resp := executeTestDB(t, nk, db, &GraphQLRequest{
+		Query:     `query ($ids: [String!]!) { usersById(ids: $ids) { username } userByCustomId(customId: "custom") { username } }`,
+		Variables: map[string]interface{}{"ids": []interface{}{second, "missing", first}},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	data := resp.Data.(map[string]interface{})
+	users := data["usersById"].([]interface{})
+	if len(users) != 2 || users[0].(map[string]interface{})["username"] != "second" || users[1].(map[string]interface{})["username"] != "first" {
+		t.Fatalf("expected the users found in the order requested but got %+v", users)
+	}
+	if data["userByCustomId"].(map[string]interface{})["username"] != "second" {
+		t.Fatalf("expected the user with the custom id but got %+v", data["userByCustomId"])
+	}
+
+	mock.ExpectQuery("SELECT id, username FROM users WHERE username LIKE").
+		WithArgs(`f\_%`, "", systemUserID, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(first, "first"))
+
+	resp = executeTestDB(t, nk, db, &GraphQLRequest{
+		Query: `{ searchUsers(usernamePrefix: "f_", first: 1) { edges { node { id } } pageInfo { hasNextPage } } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	conn := resp.Data.(map[string]interface{})["searchUsers"].(map[string]interface{})
+	if edges := conn["edges"].([]interface{}); len(edges) != 1 || conn["pageInfo"].(map[string]interface{})["hasNextPage"] != false {
+		t.Fatalf("expected a single page with the matching user but got %+v", conn)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet database expectations: %s", err)
+	}
+}
+
+func TestFriends(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	userID, friendID := nk.AddUser("someone"), nk.AddUser("friend")
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error while creating the mock database: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	for _, id := range []string{userID, friendID} {
+		mock.ExpectQuery("SELECT id FROM users WHERE id = \\$1 FOR UPDATE").WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+	}
+	for _, pair := range [][]string{{userID, friendID}, {friendID, userID}} {
+		mock.ExpectQuery("SELECT state FROM user_edge").WithArgs(pair[0], pair[1]).WillReturnRows(sqlmock.NewRows([]string{"state"}))
+		mock.ExpectExec("UPDATE user_edge SET state").WithArgs(pair[0], pair[1], 0).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO user_edge").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE users SET edge_count = edge_count \\+ 1").WithArgs(pair[0]).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec("INSERT INTO storage").
+		WithArgs(audit.CollectionID, sqlmock.AnyArg(), systemUserID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// A user who is missing from Nakama is left out while the page still ends after its edge
+	missingID := "00000000-0000-0000-0000-00000000dead"
+	mock.ExpectQuery("SELECT destination_id, state, position, update_time FROM user_edge").
+		WithArgs(userID, 0, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"destination_id", "state", "position", "update_time"}).
+			AddRow(friendID, 0, 1, time.Now()).
+			AddRow(missingID, 0, 2, time.Now()))
+
+	resp := executeTestDB(t, nk, db, &GraphQLRequest{
+		Query:     `mutation ($userId: String!, $friendId: String!) { addFriend(userId: $userId, friendId: $friendId) }`,
+		Variables: map[string]interface{}{"userId": userID, "friendId": friendID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	resp = executeTestDB(t, nk, db, &GraphQLRequest{
+		Query:     `query ($id: String!) { userById(id: $id) { friends(state: FRIEND, first: 2) { edges { node { user { username } state } } pageInfo { endCursor } } } }`,
+		Variables: map[string]interface{}{"id": userID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	friends := resp.Data.(map[string]interface{})["userById"].(map[string]interface{})["friends"].(map[string]interface{})
+	edges := friends["edges"].([]interface{})
+	if len(edges) != 1 || edges[0].(map[string]interface{})["node"].(map[string]interface{})["state"] != "FRIEND" {
+		t.Fatalf("expected a single friend but got %+v", friends)
+	}
+	if cursor := friends["pageInfo"].(map[string]interface{})["endCursor"]; cursor != encodeEdgeCursor(0, 2) {
+		t.Fatalf("expected the page to end after the missing user but got %v", cursor)
+	}
+
+	// Members cannot be added to groups which do not exist
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM groups").WithArgs("nogroup").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	resp = executeTestDB(t, nk, db, &GraphQLRequest{
+		Query:     `mutation ($userId: String!) { addGroupMember(groupId: "nogroup", userId: $userId) }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "no group with id `nogroup`") {
+		t.Fatalf("expected a missing group error but got %+v", resp.Errors)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet database expectations: %s", err)
+	}
+}
+
+func TestScoreCorrection(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	ctx := context.Background()
+	first, second := nk.AddUser("first"), nk.AddUser("second")
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query: `mutation { createLeaderboard(id: "weekly", operator: BEST) }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	for userID, score := range map[string]int64{first: 500, second: 300} {
+		if _, err := nk.LeaderboardRecordWrite(ctx, "weekly", userID, "", score, 0, nil); err != nil {
+			t.Fatalf("error while writing a record: %s", err)
+		}
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($ownerId: String!) { correctLeaderboardScore(id: "weekly", ownerId: $ownerId, score: 100) { score } }`,
+		Variables: map[string]interface{}{"ownerId": first},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query: `{ leaderboardRecords(id: "weekly", limit: 2) { records { owner { username } score rank } } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	records := resp.Data.(map[string]interface{})["leaderboardRecords"].(map[string]interface{})["records"].([]interface{})
+	if len(records) != 2 {
+		t.Fatalf("expected both records but got %+v", records)
+	}
+	top := records[0].(map[string]interface{})
+	if top["owner"].(map[string]interface{})["username"] != "second" || records[1].(map[string]interface{})["score"] != float64(100) {
+		t.Fatalf("expected the corrected score to rank last but got %+v", records)
+	}
+
+	// A correction which cannot be written puts the record it deleted back
+	failure := errors.New("tournament is not active")
+	_, err := resolveScoreCorrection(graphql.ResolveParams{
+		Context: context.WithValue(ctx, GRAPHQL_CTX_NAKAMA_MODULE, nk),
+		Args:    map[string]interface{}{"id": "weekly", "ownerId": second, "score": int64(900), "subscore": int64(0)},
+	}, "correctTournamentScore", func(ctx context.Context, id, ownerID, username string, score, subscore int64, metadata map[string]interface{}) (*api.LeaderboardRecord, error) {
+		if score == 900 {
+			return nil, failure
+		}
+		return nk.LeaderboardRecordWrite(ctx, id, ownerID, username, score, subscore, metadata)
+	})
+	if err != failure {
+		t.Fatalf("expected the error of the write but got %v", err)
+	}
+	if _, owned, _, _, err := nk.LeaderboardRecordsList(ctx, "weekly", []string{second}, 0, "", 0); err != nil || len(owned) != 1 || owned[0].GetScore() != 300 {
+		t.Fatalf("expected the previous record to be restored but got %+v, %v", owned, err)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `query ($ownerId: String!) { leaderboardRecordsAroundOwner(id: "weekly", ownerId: $ownerId, limit: 0) { score } }`,
+		Variables: map[string]interface{}{"ownerId": first},
+	})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "`limit`") {
+		t.Fatalf("expected the limit to be refused but got %+v", resp.Errors)
+	}
+}
+
+func TestTournamentScoreCorrection(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	ctx := context.Background()
+	userID := nk.AddUser("player")
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query: `mutation {
+			limited: createTournament(id: "limited", operator: BEST, duration: 3600, maxNumScore: 3, joinRequired: true)
+			open: createTournament(id: "open", operator: BEST, duration: 3600, joinRequired: true)
+		}`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	for _, id := range []string{"limited", "open"} {
+		if err := nk.TournamentJoin(ctx, id, userID, "player"); err != nil {
+			t.Fatalf("error while joining a tournament: %s", err)
+		}
+		for _, score := range []int64{200, 500} {
+			if _, err := nk.TournamentRecordWrite(ctx, id, userID, "player", score, 0, nil); err != nil {
+				t.Fatalf("error while writing a tournament record: %s", err)
+			}
+		}
+	}
+
+	// Correcting a record limited to a number of scores would give back the scores it used
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($ownerId: String!) { correctTournamentScore(id: "limited", ownerId: $ownerId, score: 100) { score } }`,
+		Variables: map[string]interface{}{"ownerId": userID},
+	})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "limited to 3 scores") {
+		t.Fatalf("expected the correction to be refused but got %+v", resp)
+	}
+	if _, owned, _, _, err := nk.LeaderboardRecordsList(ctx, "limited", []string{userID}, 0, "", 0); err != nil || len(owned) != 1 || owned[0].GetScore() != 500 || owned[0].GetNumScore() != 2 {
+		t.Fatalf("expected the record to be left as is but got %+v, %v", owned, err)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($ownerId: String!) { correctTournamentScore(id: "open", ownerId: $ownerId, score: 100) { score } }`,
+		Variables: map[string]interface{}{"ownerId": userID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	if _, owned, _, _, err := nk.LeaderboardRecordsList(ctx, "open", []string{userID}, 0, "", 0); err != nil || len(owned) != 1 || owned[0].GetScore() != 100 {
+		t.Fatalf("expected the corrected record but got %+v, %v", owned, err)
+	}
+}
+
+func TestWalletUpdate(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	userID := nk.AddUser("spender")
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($userId: String!) { walletUpdate(userId: $userId, changeset: "{\"gold\": 10}", reason: " ") { walletJson } }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "`reason`") {
+		t.Fatalf("expected an empty reason to be rejected but got %+v", resp.Errors)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($userId: String!) { walletUpdate(userId: $userId, changeset: "{\"gold\": 10, \"gems\": {\"red\": 2}}", reason: "refund") { wallet { currency amount } } }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	wallet := resp.Data.(map[string]interface{})["walletUpdate"].(map[string]interface{})["wallet"].([]interface{})
+	if len(wallet) != 2 || wallet[0].(map[string]interface{})["currency"] != "gems.red" || wallet[1].(map[string]interface{})["amount"] != float64(10) {
+		t.Fatalf("expected the updated balances but got %+v", wallet)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `query ($userId: String!) { userById(id: $userId) { ledger(first: 1) { edges { node { metadata { key value } } } pageInfo { hasNextPage } } } }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	ledger := resp.Data.(map[string]interface{})["userById"].(map[string]interface{})["ledger"].(map[string]interface{})
+	edges := ledger["edges"].([]interface{})
+	if len(edges) != 1 {
+		t.Fatalf("expected a single ledger item but got %+v", edges)
+	}
+	metadata := edges[0].(map[string]interface{})["node"].(map[string]interface{})["metadata"].([]interface{})
+	if len(metadata) != 2 || metadata[1].(map[string]interface{})["value"] != "refund" {
+		t.Fatalf("expected the reason in the ledger item metadata but got %+v", metadata)
+	}
+}
+
+// failingAuditNakamaModule fails every storage write holding an audit entry
+type failingAuditNakamaModule struct {
+	*fake.NakamaModule
+}
+
+func (nk *failingAuditNakamaModule) StorageWrite(ctx context.Context, writes []*runtime.StorageWrite) ([]*api.StorageObjectAck, error) {
+	for _, write := range writes {
+		if write.Collection == audit.CollectionID {
+			return nil, errors.New("audit log unavailable")
+		}
+	}
+	return nk.NakamaModule.StorageWrite(ctx, writes)
+}
+
+func TestWalletUpdateUnaudited(t *testing.T) {
+
+	nk := &failingAuditNakamaModule{fake.NewNakamaModule()}
+	userID := nk.AddUser("spender")
+
+	init := rpctest.New(t, nk)
+	if err := RegisterGraphQL(init); err != nil {
+		t.Fatalf("error while registering graphql: %s", err)
+	}
+
+	// A wallet updated without its audit entry is still updated, and must not look otherwise to be updated again
+	var resp GraphQLResponse
+	if err := init.CallJSON(context.Background(), "graphql", &GraphQLRequest{
+		Query:     `mutation ($userId: String!) { walletUpdate(userId: $userId, changeset: "{\"gold\": 10}", reason: "refund") { walletJson } }`,
+		Variables: map[string]interface{}{"userId": userID},
+	}, &resp); err != nil {
+		t.Fatalf("error while calling graphql: %s", err)
+	}
+	if len(resp.Errors) > 0 {
+		t.Fatalf("expected the update to succeed without its audit entry but got %+v", resp.Errors)
+	}
+
+	account, err := nk.AccountGetId(context.Background(), userID)
+	if err != nil || !strings.Contains(account.GetWallet(), `"gold":10`) {
+		t.Fatalf("expected the wallet to be updated once but got %+v, %v", account, err)
+	}
+}
+
+func TestMatchesAndStreams(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	userID := nk.AddUser("player")
+
+	nk.AddMatch(&api.Match{Authoritative: false, Size: 2})
+	matchID := nk.AddMatch(&api.Match{Authoritative: true, Label: &wrappers.StringValue{Value: "ranked"}, Size: 1})
+	subject, node := strings.Split(matchID, ".")[0], strings.Split(matchID, ".")[1]
+
+	if _, err := nk.StreamUserJoin(6, subject, "", node, userID, "session", false, false, ""); err != nil {
+		t.Fatalf("error while joining the match stream: %s", err)
+	}
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query: `{ matches(label: "ranked") { id label size presences { sessionId user { username } } } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	matches := resp.Data.(map[string]interface{})["matches"].([]interface{})
+	if len(matches) != 1 || matches[0].(map[string]interface{})["id"] != matchID {
+		t.Fatalf("expected only the labelled match but got %+v", matches)
+	}
+	presences := matches[0].(map[string]interface{})["presences"].([]interface{})
+	if len(presences) != 1 || presences[0].(map[string]interface{})["user"].(map[string]interface{})["username"] != "player" {
+		t.Fatalf("expected the player in the match but got %+v", presences)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query: `mutation ($subject: String!, $node: String!, $userId: String!) {
+			sendToStream(mode: MATCH_AUTHORITATIVE, subject: $subject, label: $node, data: "ping")
+			kickFromStream(mode: MATCH_AUTHORITATIVE, subject: $subject, label: $node, userId: $userId, sessionId: "session")
+		}`,
+		Variables: map[string]interface{}{"subject": subject, "node": node, "userId": userID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	if data := nk.StreamData(6, subject, "", node); len(data) != 1 || data[0] != "ping" {
+		t.Fatalf("expected the data sent to the match stream but got %+v", data)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `query ($subject: String!, $node: String!) { streamPresences(mode: MATCH_AUTHORITATIVE, subject: $subject, label: $node) { userId } }`,
+		Variables: map[string]interface{}{"subject": subject, "node": node},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	if presences := resp.Data.(map[string]interface{})["streamPresences"].([]interface{}); len(presences) != 0 {
+		t.Fatalf("expected the kicked session to leave the stream but got %+v", presences)
+	}
+}
+
+func TestNotifications(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	first, second := nk.AddUser("first"), nk.AddUser("second")
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($userIds: [String!]!) { sendNotification(userIds: $userIds, subject: "maintenance", content: "{\"minutes\": 5}", code: 7) }`,
+		Variables: map[string]interface{}{"userIds": []interface{}{first, second}},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	if sent := resp.Data.(map[string]interface{})["sendNotification"]; sent != float64(2) {
+		t.Fatalf("expected a notification per user but got %+v", sent)
+	}
+
+	for _, userID := range []string{first, second} {
+		notifications := nk.Notifications(userID)
+		if len(notifications) != 1 || notifications[0].GetSubject() != "maintenance" || !notifications[0].GetPersistent() {
+			t.Fatalf("expected the notification to be sent to `%s` but got %+v", userID, notifications)
+		}
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error while creating the mock database: %s", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT id, subject, content, code, sender_id, create_time FROM notification").
+		WithArgs(first, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "subject", "content", "code", "sender_id", "create_time"}).
+			AddRow("b", "maintenance", `{"minutes": 5}`, 7, systemUserID, now).
+			AddRow("a", "welcome", `{}`, 1, second, now.Add(-time.Hour)))
+
+	resp = executeTestDB(t, nk, db, &GraphQLRequest{
+		Query:     `query ($id: String!) { userById(id: $id) { notifications(first: 1) { edges { node { subject senderId } } pageInfo { hasNextPage } } } }`,
+		Variables: map[string]interface{}{"id": first},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	notifications := resp.Data.(map[string]interface{})["userById"].(map[string]interface{})["notifications"].(map[string]interface{})
+	edges := notifications["edges"].([]interface{})
+	node := edges[0].(map[string]interface{})["node"].(map[string]interface{})
+	if len(edges) != 1 || node["subject"] != "maintenance" || node["senderId"] != nil {
+		t.Fatalf("expected the latest notification sent by the server but got %+v", edges)
+	}
+	if !notifications["pageInfo"].(map[string]interface{})["hasNextPage"].(bool) {
+		t.Fatalf("expected another page of notifications")
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query: `mutation { writeStorage(collection: "config", key: "motd", value: "{\"text\":\"hi\"}") { version } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	objs, _, err := nk.StorageList(context.Background(), "", audit.CollectionID, 10, "")
+	if err != nil || len(objs) != 1 {
+		t.Fatalf("expected a single audit entry but got %+v, %v", objs, err)
+	}
+	var entry audit.Entry
+	if err := json.Unmarshal([]byte(objs[0].GetValue()), &entry); err != nil || entry.RequestID == "" {
+		t.Fatalf("expected the entry to carry the request id but got %+v, %v", entry, err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error while creating the mock database: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT value FROM storage WHERE collection").
+		WithArgs(audit.CollectionID, systemUserID, "writeStorage", `storage:config/%`, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow(objs[0].GetValue()))
+
+	resp = executeTestDB(t, nk, db, &GraphQLRequest{
+		Query: `{ auditLog(first: 1, action: "writeStorage", targetPrefix: "storage:config/") { edges { node { actor target after requestId } } pageInfo { hasNextPage } } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	log := resp.Data.(map[string]interface{})["auditLog"].(map[string]interface{})
+	edges := log["edges"].([]interface{})
+	if len(edges) != 1 || log["pageInfo"].(map[string]interface{})["hasNextPage"] != false {
+		t.Fatalf("expected a single page with the entry but got %+v", log)
+	}
+	node := edges[0].(map[string]interface{})["node"].(map[string]interface{})
+	if node["target"] != "storage:config/motd/" || node["requestId"] != entry.RequestID || node["after"].(map[string]interface{})["text"] != "hi" {
+		t.Fatalf("unexpected entry %+v", node)
+	}
+}
+
+type testRecord struct {
+	Score int64 `json:"score"`
+}
+
+type testMatchStats struct {
+	testRecord
+	MatchesPlayed uint        `json:"matchesPlayed"`
+	Best          *testRecord `json:"best"`
+	History       []int       `json:"history"`
+}
+
+func TestStorageModels(t *testing.T) {
+
+	thawSchema()
+
+	nk := fake.NewNakamaModule()
+	userID := nk.AddUser("someone")
+
+	if err := RegisterModels(&storage.CollectionAccessor{
+		CollectionID: "match_stats",
+		KeyID:        "main",
+		ModelFactory: func() interface{} { return new(testMatchStats) },
+	}); err != nil {
+		t.Fatalf("error while registering models: %s", err)
+	}
+
+	if _, err := nk.StorageWrite(context.Background(), []*runtime.StorageWrite{{
+		Collection: "match_stats",
+		Key:        "main",
+		UserID:     userID,
+		Value:      `{"score": 9000000000, "matchesPlayed": 3, "best": {"score": 12}, "history": [4, 8]}`,
+	}}); err != nil {
+		t.Fatalf("error while writing stats: %s", err)
+	}
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query: `query ($id: String!) { userById(id: $id) { storage(collection: "match_stats") { edges { node {
+			json
+			valueAt(path: "history.1")
+			missing: valueAt(path: "history.2")
+			model { ... on testMatchStats { score matchesPlayed best { score } history } }
+		} } } } }`,
+		Variables: map[string]interface{}{"id": userID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	node := resp.Data.(map[string]interface{})["userById"].(map[string]interface{})["storage"].(map[string]interface{})["edges"].([]interface{})[0].(map[string]interface{})["node"].(map[string]interface{})
+	if node["json"].(map[string]interface{})["matchesPlayed"] != float64(3) || node["valueAt"] != float64(8) || node["missing"] != nil {
+		t.Fatalf("expected the value to be explorable as json but got %+v", node)
+	}
+
+	model := node["model"].(map[string]interface{})
+	if model["score"] != float64(9000000000) || model["matchesPlayed"] != float64(3) || model["best"].(map[string]interface{})["score"] != float64(12) || len(model["history"].([]interface{})) != 2 {
+		t.Fatalf("expected the value decoded into the model but got %+v", model)
+	}
+}
+
+type testTotals struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+}
+
+func TestAccessors(t *testing.T) {
+
+	thawSchema()
+
+	nk := fake.NewNakamaModule()
+	userID := nk.AddUser("someone")
+
+	totalsAccessor := &storage.CollectionAccessor{
+		CollectionID:   "match-totals",
+		KeyID:          "main",
+		ModelFactory:   func() interface{} { return new(testTotals) },
+		DefaultFactory: func() interface{} { return &testTotals{} },
+	}
+
+	if err := RegisterAccessors(totalsAccessor); err != nil {
+		t.Fatalf("error while registering accessors: %s", err)
+	}
+	if err := RegisterAccessors(&storage.CollectionAccessor{CollectionID: "ledger", ModelFactory: func() interface{} { return new(testTotals) }}); err == nil {
+		t.Fatalf("expected a conflict with the ledger field of users")
+	}
+	if err := RegisterAccessors(&storage.CollectionAccessor{CollectionID: "users-by-id", ModelFactory: func() interface{} { return new(testTotals) }}); err == nil {
+		t.Fatalf("expected a conflict with the usersById query")
+	}
+	if _, has := modelCollections["users-by-id"]; has {
+		t.Fatalf("expected the model of a conflicting accessor not to be registered")
+	}
+
+	resp := executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($userId: String!) { setMatchTotals(userId: $userId, value: {wins: 3, loses: 1}) { wins } }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "loses") {
+		t.Fatalf("expected the misspelled field to be rejected but got %+v", resp.Errors)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `mutation ($userId: String!) { setMatchTotals(userId: $userId, value: {wins: 3, losses: 1}) { wins } }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	totals, _, err := totalsAccessor.Get(context.Background(), nk, userID)
+	if err != nil || totals.(*testTotals).Losses != 1 {
+		t.Fatalf("expected the totals to be saved but got %+v, %v", totals, err)
+	}
+	entries, _, err := nk.StorageList(context.Background(), "", audit.CollectionID, 10, "")
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected the change to be audited but got %+v, %v", entries, err)
+	}
+
+	resp = executeTest(t, nk, &GraphQLRequest{
+		Query:     `query ($userId: String!) { matchTotals(userId: $userId) { wins } userById(id: $userId) { matchTotals { losses } } }`,
+		Variables: map[string]interface{}{"userId": userID},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	data := resp.Data.(map[string]interface{})
+	if data["matchTotals"].(map[string]interface{})["wins"] != float64(3) || data["userById"].(map[string]interface{})["matchTotals"].(map[string]interface{})["losses"] != float64(1) {
+		t.Fatalf("expected the saved totals but got %+v", data)
+	}
+}
+
+// countingNakamaModule counts the calls made to the lookups that resolvers batch
+type countingNakamaModule struct {
+	*fake.NakamaModule
+	calls map[string]int
+}
+
+func (nk *countingNakamaModule) AccountGetId(ctx context.Context, userID string) (*api.Account, error) {
+	nk.calls["AccountGetId"]++
+	return nk.NakamaModule.AccountGetId(ctx, userID)
+}
+
+func (nk *countingNakamaModule) AccountsGetId(ctx context.Context, userIDs []string) ([]*api.Account, error) {
+	nk.calls["AccountsGetId"]++
+	return nk.NakamaModule.AccountsGetId(ctx, userIDs)
+}
+
+func (nk *countingNakamaModule) StorageRead(ctx context.Context, reads []*runtime.StorageRead) ([]*api.StorageObject, error) {
+	nk.calls["StorageRead"]++
+	return nk.NakamaModule.StorageRead(ctx, reads)
+}
+
+func TestBatchedLookups(t *testing.T) {
+
+	thawSchema()
+
+	nk := &countingNakamaModule{fake.NewNakamaModule(), map[string]int{}}
+	ids := []interface{}{nk.AddUser("first"), nk.AddUser("second"), nk.AddUser("third")}
+
+	totalsAccessor := &storage.CollectionAccessor{
+		CollectionID:   "batched_totals",
+		KeyID:          "main",
+		ModelFactory:   func() interface{} { return new(testTotals) },
+		DefaultFactory: func() interface{} { return &testTotals{} },
+	}
+	if err := RegisterAccessors(totalsAccessor); err != nil {
+		t.Fatalf("error while registering accessors: %s", err)
+	}
+	if err := totalsAccessor.Save(context.Background(), nk, ids[1].(string), &testTotals{Wins: 2}); err != nil {
+		t.Fatalf("error while saving totals: %s", err)
+	}
+	if err := RegisterGraphQL(rpctest.New(t, nk.NakamaModule)); err != nil {
+		t.Fatalf("error while registering graphql: %s", err)
+	}
+
+	resp := Execute(context.Background(), nk, &GraphQLRequest{
+		Query:     `query ($ids: [String!]!) { usersById(ids: $ids) { account { customId } batchedTotals { wins } again: account { customId } } }`,
+		Variables: map[string]interface{}{"ids": append(ids, ids[0])},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+
+	users := resp.Data.(map[string]interface{})["usersById"].([]interface{})
+	if len(users) != 4 || users[1].(map[string]interface{})["batchedTotals"].(map[string]interface{})["wins"] != 2 {
+		t.Fatalf("expected every user with their totals but got %+v", users)
+	}
+	if nk.calls["AccountsGetId"] != 1 || nk.calls["AccountGetId"] != 0 || nk.calls["StorageRead"] != 1 {
+		t.Fatalf("expected a single batched lookup of accounts and storage but got %+v", nk.calls)
+	}
+}
+
+func TestExtend(t *testing.T) {
+
+	thawSchema()
+
+	hello := &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return "hello", nil
+		},
+	}
+
+	if err := Extend(Extension{Module: "first", Queries: graphql.Fields{"hello": hello}}); err != nil {
+		t.Fatalf("error while extending the schema: %s", err)
+	}
+
+	err := Extend(Extension{Module: "second", Queries: graphql.Fields{"greeting": hello}, Mutations: graphql.Fields{"writeStorage": hello}})
+	if err == nil || !strings.Contains(err.Error(), "built-in") {
+		t.Fatalf("expected a conflict with a built-in field but got %v", err)
+	}
+
+	err = Extend(Extension{Module: "second", Queries: graphql.Fields{"hello": hello}})
+	if err == nil || !strings.Contains(err.Error(), "module `first`") {
+		t.Fatalf("expected a conflict with the first module but got %v", err)
+	}
+
+	nk := fake.NewNakamaModule()
+	resp := executeTest(t, nk, &GraphQLRequest{Query: `{ hello }`})
+	if len(resp.Errors) > 0 || resp.Data.(map[string]interface{})["hello"] != "hello" {
+		t.Fatalf("expected the extension to be queryable but got %+v", resp)
+	}
+	if resp := executeTest(t, nk, &GraphQLRequest{Query: `{ greeting }`}); len(resp.Errors) == 0 {
+		t.Fatalf("expected the conflicting extension to be left out entirely")
+	}
+
+	if err := Extend(Extension{Module: "late", Queries: graphql.Fields{"late": hello}}); err == nil || !strings.Contains(err.Error(), ErrSchemaFrozen.Error()) {
+		t.Fatalf("expected extending the built schema to fail but got %v", err)
+	}
+	if err := ConfigureRootQuery(func(rootQuery *graphql.Object) error { return nil }); err != ErrSchemaFrozen {
+		t.Fatalf("expected configuring the built schema to fail but got %v", err)
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+
+	thawSchema()
+
+	events := make(chan interface{})
+	if err := Extend(Extension{
+		Module: "counter",
+		Subscriptions: Subscriptions{
+			"counted": &Subscription{
+				Field: &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				Subscribe: func(p graphql.ResolveParams) (<-chan interface{}, error) {
+					return events, nil
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("error while extending the schema: %s", err)
+	}
+	if err := buildSchema(); err != nil {
+		t.Fatalf("error while building the schema: %s", err)
+	}
+
+	nk := fake.NewNakamaModule()
+	nk.AddMatch(&api.Match{Authoritative: false, Size: 2})
+	nk.AddMatch(&api.Match{Authoritative: true, Size: 3})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	counted := Subscribe(ctx, nk, &GraphQLRequest{Query: `subscription { counted }`})
+	for i := 1; i <= 2; i++ {
+		events <- i
+		resp := <-counted
+		if len(resp.Errors) > 0 || resp.Data.(map[string]interface{})["counted"] != i {
+			t.Fatalf("expected event %d but got %+v", i, resp)
+		}
+	}
+	close(events)
+	if _, open := <-counted; open {
+		t.Fatalf("expected the responses to end with the events")
+	}
+
+	metricsCtx, stop := context.WithCancel(ctx)
+	metrics := Subscribe(metricsCtx, nk, &GraphQLRequest{Query: `subscription { metrics(intervalSeconds: 1) { authoritativeMatches relayedMatches players } }`})
+	resp := <-metrics
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	sample := resp.Data.(map[string]interface{})["metrics"].(map[string]interface{})
+	if sample["authoritativeMatches"] != 1 || sample["relayedMatches"] != 1 || sample["players"] != 5 {
+		t.Fatalf("unexpected metrics %+v", sample)
+	}
+	stop()
+	for range metrics {
+	}
+
+	resp = <-Subscribe(ctx, nk, &GraphQLRequest{Query: `subscription { metrics(intervalSeconds: 0) { players } }`})
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "intervalSeconds") {
+		t.Fatalf("expected an invalid interval to fail but got %+v", resp)
+	}
+
+	resp = <-Subscribe(ctx, nk, &GraphQLRequest{Query: `{ matches { size } }`})
+	if len(resp.Errors) > 0 || len(resp.Data.(map[string]interface{})["matches"].([]interface{})) != 2 {
+		t.Fatalf("expected queries to get a single response but got %+v", resp)
+	}
+
+	if resp := executeTest(t, nk, &GraphQLRequest{Query: `subscription { counted }`}); len(resp.Errors) == 0 {
+		t.Fatalf("expected subscriptions to fail over the rpc")
+	}
+}
+
+func TestPersistedQueries(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	nk.AddUser("someone")
+
+	query := `{ userByUsername(username: "someone") { username } }`
+	hash := queryHash(query)
+	persisted := &RequestExtensions{PersistedQuery: &PersistedQuery{Version: 1, Sha256Hash: hash}}
+
+	resp := executeTest(t, nk, &GraphQLRequest{Extensions: persisted})
+	if len(resp.Errors) != 1 || resp.Errors[0].Message != "PersistedQueryNotFound" {
+		t.Fatalf("expected an unknown hash to be reported but got %+v", resp)
+	}
+
+	if resp := executeTest(t, nk, &GraphQLRequest{Query: `{ matches { id } }`, Extensions: persisted}); len(resp.Errors) == 0 {
+		t.Fatalf("expected a query not matching its hash to fail")
+	}
+
+	for _, request := range []*GraphQLRequest{{Query: query, Extensions: persisted}, {Extensions: persisted}} {
+		resp := executeTest(t, nk, request)
+		if len(resp.Errors) > 0 || resp.Data.(map[string]interface{})["userByUsername"] == nil {
+			t.Fatalf("expected the persisted query to be executed but got %+v", resp)
+		}
+	}
+
+	registered := RegisterPersistedQuery(`{ matches { id } }`)
+	resp = executeTest(t, nk, &GraphQLRequest{Extensions: &RequestExtensions{PersistedQuery: &PersistedQuery{Version: 1, Sha256Hash: registered}}})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("expected the registered query to be executed but got %+v", resp.Errors)
+	}
+
+	if resp := ExecuteQuery(context.Background(), nk, &GraphQLRequest{Query: `mutation { walletUpdate(userId: "", changeset: "{}", reason: "") { wallet { currency } } }`}); len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "mutation") {
+		t.Fatalf("expected ExecuteQuery to refuse mutations but got %+v", resp)
+	}
+}
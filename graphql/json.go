@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+var (
+	jsonType = graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "JSON",
+		Description: "Any json value, objects and arrays included.",
+		Serialize: func(value interface{}) interface{} {
+			if raw, ok := value.(json.RawMessage); ok {
+				var decoded interface{}
+				if err := json.Unmarshal(raw, &decoded); err != nil {
+					return nil
+				}
+				return decoded
+			}
+			return value
+		},
+		ParseValue: func(value interface{}) interface{} {
+			return value
+		},
+		ParseLiteral: parseJSONLiteral,
+	})
+)
+
+func parseJSONLiteral(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	case *ast.IntValue:
+		if n, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return n
+		}
+	case *ast.FloatValue:
+		if n, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return n
+		}
+	case *ast.ListValue:
+		list := []interface{}{}
+		for _, v := range valueAST.Values {
+			list = append(list, parseJSONLiteral(v))
+		}
+		return list
+	case *ast.ObjectValue:
+		object := map[string]interface{}{}
+		for _, field := range valueAST.Fields {
+			object[field.Name.Value] = parseJSONLiteral(field.Value)
+		}
+		return object
+	}
+	return nil
+}
+
+// valueAt follows a dotted path through decoded json, where array elements are selected by their index,
+// returning false if there is nothing at the path
+func valueAt(value interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return value, true
+	}
+	for _, part := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			next, has := v[part]
+			if !has {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
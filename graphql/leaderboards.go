@@ -0,0 +1,820 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+type leaderboardRecordList struct {
+	records      []*api.LeaderboardRecord
+	ownerRecords []*api.LeaderboardRecord
+	nextCursor   string
+	prevCursor   string
+}
+
+var (
+	longType = graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "Long",
+		Description: "A 64-bit integer, used for scores which may exceed the range of `Int`.",
+		Serialize: func(value interface{}) interface{} {
+			switch value := value.(type) {
+			case int64:
+				return value
+			case int:
+				return int64(value)
+			case int32:
+				return int64(value)
+			}
+			return nil
+		},
+		ParseValue: func(value interface{}) interface{} {
+			switch value := value.(type) {
+			case float64:
+				return int64(value)
+			case int:
+				return int64(value)
+			case int64:
+				return value
+			case string:
+				if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+					return n
+				}
+			}
+			return nil
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			switch valueAST := valueAST.(type) {
+			case *ast.IntValue:
+				if n, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+					return n
+				}
+			case *ast.StringValue:
+				if n, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+					return n
+				}
+			}
+			return nil
+		},
+	})
+
+	sortOrderType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "SortOrder",
+		Description: "The order in which leaderboard records are ranked.",
+		Values: graphql.EnumValueConfigMap{
+			"ASC":  &graphql.EnumValueConfig{Value: "asc", Description: "Lower scores rank first."},
+			"DESC": &graphql.EnumValueConfig{Value: "desc", Description: "Higher scores rank first."},
+		},
+	})
+
+	operatorType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "LeaderboardOperator",
+		Description: "How new scores are combined with existing ones.",
+		Values: graphql.EnumValueConfigMap{
+			"BEST": &graphql.EnumValueConfig{Value: "best", Description: "Keep the best score."},
+			"SET":  &graphql.EnumValueConfig{Value: "set", Description: "Keep the latest score."},
+			"INCR": &graphql.EnumValueConfig{Value: "incr", Description: "Add new scores to the existing one."},
+		},
+	})
+
+	leaderboardRecordType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "LeaderboardRecord",
+		Description: "The score of an owner in a leaderboard or tournament.",
+		Fields: graphql.Fields{
+			"leaderboardId": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The id of the leaderboard or tournament.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.LeaderboardRecord).GetLeaderboardId(), nil
+				},
+			},
+			"ownerId": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The id of the owner of the record, usually a user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.LeaderboardRecord).GetOwnerId(), nil
+				},
+			},
+			"owner": &graphql.Field{
+				Type:        userType,
+				Description: "The user owning the record, null if it is not owned by a user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadUser(p, p.Source.(*api.LeaderboardRecord).GetOwnerId())
+				},
+			},
+			"username": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The username of the owner when the record was written.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if username := p.Source.(*api.LeaderboardRecord).GetUsername(); username != nil {
+						return username.GetValue(), nil
+					}
+					return nil, nil
+				},
+			},
+			"score": &graphql.Field{
+				Type:        graphql.NewNonNull(longType),
+				Description: "The score of the record.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.LeaderboardRecord).GetScore(), nil
+				},
+			},
+			"subscore": &graphql.Field{
+				Type:        graphql.NewNonNull(longType),
+				Description: "The subscore of the record, breaking ties between equal scores.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.LeaderboardRecord).GetSubscore(), nil
+				},
+			},
+			"numScore": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of scores submitted for the record.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.LeaderboardRecord).GetNumScore(), nil
+				},
+			},
+			"maxNumScore": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The maximum number of scores that may be submitted for the record.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.LeaderboardRecord).GetMaxNumScore()), nil
+				},
+			},
+			"metadata": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The metadata of the record, a json object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.LeaderboardRecord).GetMetadata(), nil
+				},
+			},
+			"rank": &graphql.Field{
+				Type:        graphql.NewNonNull(longType),
+				Description: "The rank of the record, starting from 1.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.LeaderboardRecord).GetRank(), nil
+				},
+			},
+			"createTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the record was created.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(*api.LeaderboardRecord).GetCreateTime().GetSeconds(), 0), nil
+				},
+			},
+			"updateTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the record was last updated.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(*api.LeaderboardRecord).GetUpdateTime().GetSeconds(), 0), nil
+				},
+			},
+			"expiryTime": &graphql.Field{
+				Type:        graphql.DateTime,
+				Description: "The time the record expires, null if it never does.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalTime(p.Source.(*api.LeaderboardRecord).GetExpiryTime()), nil
+				},
+			},
+		},
+	})
+
+	leaderboardRecordListType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "LeaderboardRecordList",
+		Description: "A page of leaderboard records.",
+		Fields: graphql.Fields{
+			"records": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(leaderboardRecordType))),
+				Description: "The records in this page, ordered by rank.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*leaderboardRecordList).records, nil
+				},
+			},
+			"ownerRecords": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(leaderboardRecordType))),
+				Description: "The records of the owners asked for, regardless of the page.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*leaderboardRecordList).ownerRecords, nil
+				},
+			},
+			"nextCursor": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The cursor of the next page, null if this is the last one.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalString(p.Source.(*leaderboardRecordList).nextCursor), nil
+				},
+			},
+			"prevCursor": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The cursor of the previous page, null if this is the first one.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalString(p.Source.(*leaderboardRecordList).prevCursor), nil
+				},
+			},
+		},
+	})
+
+	leaderboardRecordsArgs = graphql.FieldConfigArgument{
+		"limit": &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: defaultPageSize,
+			Description:  fmt.Sprintf("The number of records to return, up to %d.", maxPageSize),
+		},
+		"cursor": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "The cursor of the page to return.",
+		},
+		"ownerIds": &graphql.ArgumentConfig{
+			Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+			Description: "Owners whose records are returned in `ownerRecords`.",
+		},
+	}
+
+	tournamentType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Tournament",
+		Description: "A leaderboard which is active for a limited duration.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The id of the tournament.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Tournament).GetId(), nil
+				},
+			},
+			"title": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The title of the tournament.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Tournament).GetTitle(), nil
+				},
+			},
+			"description": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The description of the tournament.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Tournament).GetDescription(), nil
+				},
+			},
+			"category": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The category of the tournament.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.Tournament).GetCategory()), nil
+				},
+			},
+			"sortOrder": &graphql.Field{
+				Type:        graphql.NewNonNull(sortOrderType),
+				Description: "The order in which records are ranked.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					// Nakama sorts ascending for 0 and descending for 1
+					if p.Source.(*api.Tournament).GetSortOrder() == 0 {
+						return "asc", nil
+					}
+					return "desc", nil
+				},
+			},
+			"size": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of users in the tournament.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.Tournament).GetSize()), nil
+				},
+			},
+			"maxSize": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The maximum number of users in the tournament.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.Tournament).GetMaxSize()), nil
+				},
+			},
+			"maxNumScore": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The maximum number of scores a user may submit.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.Tournament).GetMaxNumScore()), nil
+				},
+			},
+			"canEnter": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether users can currently enter the tournament.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Tournament).GetCanEnter(), nil
+				},
+			},
+			"metadata": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The metadata of the tournament, a json object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Tournament).GetMetadata(), nil
+				},
+			},
+			"duration": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of seconds the tournament is active for once started.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.Tournament).GetDuration()), nil
+				},
+			},
+			"createTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the tournament was created.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(*api.Tournament).GetCreateTime().GetSeconds(), 0), nil
+				},
+			},
+			"startTime": &graphql.Field{
+				Type:        graphql.DateTime,
+				Description: "The time the tournament starts.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalTime(p.Source.(*api.Tournament).GetStartTime()), nil
+				},
+			},
+			"endTime": &graphql.Field{
+				Type:        graphql.DateTime,
+				Description: "The time the tournament ends, null if it never does.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalTime(p.Source.(*api.Tournament).GetEndTime()), nil
+				},
+			},
+			"endActive": &graphql.Field{
+				Type:        graphql.DateTime,
+				Description: "The time the current period of the tournament ends.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if endActive := p.Source.(*api.Tournament).GetEndActive(); endActive > 0 {
+						return time.Unix(int64(endActive), 0), nil
+					}
+					return nil, nil
+				},
+			},
+			"nextReset": &graphql.Field{
+				Type:        graphql.DateTime,
+				Description: "The time the tournament next resets, null if it does not.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if nextReset := p.Source.(*api.Tournament).GetNextReset(); nextReset > 0 {
+						return time.Unix(int64(nextReset), 0), nil
+					}
+					return nil, nil
+				},
+			},
+			"records": &graphql.Field{
+				Type:        graphql.NewNonNull(leaderboardRecordListType),
+				Description: "The records of the current period of the tournament.",
+				Args:        leaderboardRecordsArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveLeaderboardRecords(p, p.Source.(*api.Tournament).GetId())
+				},
+			},
+		},
+	})
+
+	tournamentListType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TournamentList",
+		Description: "A page of tournaments.",
+		Fields: graphql.Fields{
+			"tournaments": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tournamentType))),
+				Description: "The tournaments in this page.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.TournamentList).GetTournaments(), nil
+				},
+			},
+			"cursor": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The cursor of the next page, null if this is the last one.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalString(p.Source.(*api.TournamentList).GetCursor()), nil
+				},
+			},
+		},
+	})
+
+	leaderboardRecordsField = &graphql.Field{
+		Type:        graphql.NewNonNull(leaderboardRecordListType),
+		Description: "Lists the top records of a leaderboard or tournament, along with the records of specific owners.",
+		Args: withArgs(leaderboardRecordsArgs, graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return resolveLeaderboardRecords(p, p.Args["id"].(string))
+		},
+	}
+
+	leaderboardRecordsAroundOwnerField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(leaderboardRecordType))),
+		Description: "Lists the records ranked around the record of an owner in a leaderboard or tournament.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"ownerId": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"limit": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: defaultPageSize,
+				Description:  fmt.Sprintf("The number of records to return, up to %d.", maxPageSize),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			limit, err := limitArg(p)
+			if err != nil {
+				return nil, err
+			}
+			// Nakama lists records around an owner through the tournament api, which works for any leaderboard
+			return nk.TournamentRecordsHaystack(p.Context, p.Args["id"].(string), p.Args["ownerId"].(string), limit)
+		},
+	}
+
+	tournamentsField = &graphql.Field{
+		Type:        graphql.NewNonNull(tournamentListType),
+		Description: "Lists tournaments by category and time range.",
+		Args: graphql.FieldConfigArgument{
+			"categoryStart": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 0,
+			},
+			"categoryEnd": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 127,
+			},
+			"startTime": &graphql.ArgumentConfig{
+				Type:        graphql.DateTime,
+				Description: "Only return tournaments starting at or after this time.",
+			},
+			"endTime": &graphql.ArgumentConfig{
+				Type:        graphql.DateTime,
+				Description: "Only return tournaments ending at or before this time.",
+			},
+			"limit": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: defaultPageSize,
+				Description:  fmt.Sprintf("The number of tournaments to return, up to %d.", maxPageSize),
+			},
+			"cursor": &graphql.ArgumentConfig{
+				Type:        graphql.String,
+				Description: "The cursor of the page to return.",
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			limit, err := limitArg(p)
+			if err != nil {
+				return nil, err
+			}
+			cursor, _ := p.Args["cursor"].(string)
+			return nk.TournamentList(p.Context, p.Args["categoryStart"].(int), p.Args["categoryEnd"].(int),
+				unixArg(p, "startTime"), unixArg(p, "endTime"), limit, cursor)
+		},
+	}
+
+	leaderboardSettingsArgs = graphql.FieldConfigArgument{
+		"id": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"sortOrder": &graphql.ArgumentConfig{
+			Type:         sortOrderType,
+			DefaultValue: "desc",
+		},
+		"operator": &graphql.ArgumentConfig{
+			Type:         operatorType,
+			DefaultValue: "best",
+		},
+		"resetSchedule": &graphql.ArgumentConfig{
+			Type:         graphql.String,
+			DefaultValue: "",
+			Description:  "A cron expression of when records are reset, empty to never reset them.",
+		},
+		"metadata": &graphql.ArgumentConfig{
+			Type:         graphql.String,
+			DefaultValue: "{}",
+			Description:  "The metadata, a json object.",
+		},
+	}
+
+	createLeaderboardField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Creates a leaderboard, doing nothing if one with the same id exists.",
+		Args: withArgs(leaderboardSettingsArgs, graphql.FieldConfigArgument{
+			"authoritative": &graphql.ArgumentConfig{
+				Type:         graphql.Boolean,
+				DefaultValue: false,
+				Description:  "Whether only the server may submit scores.",
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			id := p.Args["id"].(string)
+
+			metadata, err := jsonObjectArg(p, "metadata")
+			if err != nil {
+				return nil, err
+			}
+
+			if err := nk.LeaderboardCreate(p.Context, id, p.Args["authoritative"].(bool), p.Args["sortOrder"].(string),
+				p.Args["operator"].(string), p.Args["resetSchedule"].(string), metadata); err != nil {
+				return nil, err
+			}
+
+			recordApplied(p, nk, "createLeaderboard", "leaderboard:"+id, nil, p.Args)
+
+			return true, nil
+		},
+	}
+
+	createTournamentField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Creates a tournament, doing nothing if one with the same id exists.",
+		Args: withArgs(leaderboardSettingsArgs, graphql.FieldConfigArgument{
+			"title": &graphql.ArgumentConfig{
+				Type:         graphql.String,
+				DefaultValue: "",
+			},
+			"description": &graphql.ArgumentConfig{
+				Type:         graphql.String,
+				DefaultValue: "",
+			},
+			"category": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 0,
+			},
+			"startTime": &graphql.ArgumentConfig{
+				Type:        graphql.DateTime,
+				Description: "The time the tournament starts, now when omitted.",
+			},
+			"endTime": &graphql.ArgumentConfig{
+				Type:        graphql.DateTime,
+				Description: "The time the tournament ends, never when omitted.",
+			},
+			"duration": &graphql.ArgumentConfig{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of seconds the tournament is active for once started.",
+			},
+			"maxSize": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 0,
+				Description:  "The maximum number of users in the tournament, 0 for no limit.",
+			},
+			"maxNumScore": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 0,
+				Description:  "The maximum number of scores a user may submit, 0 for no limit.",
+			},
+			"joinRequired": &graphql.ArgumentConfig{
+				Type:         graphql.Boolean,
+				DefaultValue: false,
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			id := p.Args["id"].(string)
+
+			metadata, err := jsonObjectArg(p, "metadata")
+			if err != nil {
+				return nil, err
+			}
+
+			if err := nk.TournamentCreate(p.Context, id, p.Args["sortOrder"].(string), p.Args["operator"].(string),
+				p.Args["resetSchedule"].(string), metadata, p.Args["title"].(string), p.Args["description"].(string),
+				p.Args["category"].(int), unixArg(p, "startTime"), unixArg(p, "endTime"), p.Args["duration"].(int),
+				p.Args["maxSize"].(int), p.Args["maxNumScore"].(int), p.Args["joinRequired"].(bool)); err != nil {
+				return nil, err
+			}
+
+			recordApplied(p, nk, "createTournament", "tournament:"+id, nil, p.Args)
+
+			return true, nil
+		},
+	}
+
+	leaderboardRecordArgs = graphql.FieldConfigArgument{
+		"id": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"ownerId": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+	}
+
+	deleteLeaderboardRecordField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Deletes the record of an owner in a leaderboard or tournament.",
+		Args:        leaderboardRecordArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			id, ownerID := p.Args["id"].(string), p.Args["ownerId"].(string)
+
+			existing, err := ownerRecord(p, id, ownerID)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := nk.LeaderboardRecordDelete(p.Context, id, ownerID); err != nil {
+				return nil, err
+			}
+
+			recordApplied(p, nk, "deleteLeaderboardRecord", leaderboardRecordTarget(id, ownerID), existing, nil)
+
+			return true, nil
+		},
+	}
+
+	scoreCorrectionArgs = withArgs(leaderboardRecordArgs, graphql.FieldConfigArgument{
+		"score": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(longType),
+		},
+		"subscore": &graphql.ArgumentConfig{
+			Type:         longType,
+			DefaultValue: int64(0),
+		},
+		"metadata": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "The metadata of the record, a json object, kept as is when omitted.",
+		},
+	})
+
+	correctLeaderboardScoreField = &graphql.Field{
+		Type:        graphql.NewNonNull(leaderboardRecordType),
+		Description: "Replaces the record of an owner in a leaderboard with the given score, regardless of the leaderboard's operator.",
+		Args:        scoreCorrectionArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			return resolveScoreCorrection(p, "correctLeaderboardScore", nk.LeaderboardRecordWrite)
+		},
+	}
+
+	correctTournamentScoreField = &graphql.Field{
+		Type:        graphql.NewNonNull(leaderboardRecordType),
+		Description: "Replaces the record of an owner in a tournament with the given score, regardless of the tournament's operator. Records of tournaments with a `maxNumScore` cannot be corrected, as they would be given back the scores they used.",
+		Args:        scoreCorrectionArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			return resolveScoreCorrection(p, "correctTournamentScore", func(ctx context.Context, id, ownerID, username string, score, subscore int64, metadata map[string]interface{}) (*api.LeaderboardRecord, error) {
+				// Deleting the record also removes the owner from tournaments which require joining
+				if err := nk.TournamentJoin(ctx, id, ownerID, username); err != nil {
+					return nil, err
+				}
+				return nk.TournamentRecordWrite(ctx, id, ownerID, username, score, subscore, metadata)
+			})
+		},
+	}
+)
+
+func resolveLeaderboardRecords(p graphql.ResolveParams, id string) (interface{}, error) {
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+
+	limit, err := limitArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, _ := p.Args["cursor"].(string)
+
+	ownerIDs := []string{}
+	if owners, ok := p.Args["ownerIds"].([]interface{}); ok {
+		for _, owner := range owners {
+			ownerIDs = append(ownerIDs, owner.(string))
+		}
+	}
+
+	records, ownerRecords, next, prev, err := nk.LeaderboardRecordsList(p.Context, id, ownerIDs, limit, cursor, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &leaderboardRecordList{records, ownerRecords, next, prev}, nil
+}
+
+// ownerRecord gets the current record of an owner in a leaderboard, nil if there is none
+func ownerRecord(p graphql.ResolveParams, id, ownerID string) (*api.LeaderboardRecord, error) {
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	_, ownerRecords, _, _, err := nk.LeaderboardRecordsList(p.Context, id, []string{ownerID}, 0, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range ownerRecords {
+		if record.GetOwnerId() == ownerID {
+			return record, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveScoreCorrection deletes the record of an owner and writes a new one with the exact score given,
+// as writing over it would combine the scores according to the leaderboard's operator.
+// Nakama cannot delete and write a record atomically, so the deleted record is written back if the new one fails.
+// Records limited to a number of scores are refused, as writing them anew would hand back the scores already used.
+func resolveScoreCorrection(p graphql.ResolveParams, action string, write func(ctx context.Context, id, ownerID, username string, score, subscore int64, metadata map[string]interface{}) (*api.LeaderboardRecord, error)) (interface{}, error) {
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	id, ownerID := p.Args["id"].(string), p.Args["ownerId"].(string)
+
+	existing, err := ownerRecord(p, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.GetMaxNumScore() > 0 {
+		return nil, fmt.Errorf("cannot correct a record limited to %d scores, as it would be given back the %d it used", existing.GetMaxNumScore(), existing.GetNumScore())
+	}
+
+	username := ""
+	existingMetadata := map[string]interface{}{}
+
+	if existing != nil {
+		username = existing.GetUsername().GetValue()
+		if existing.GetMetadata() != "" {
+			if err := json.Unmarshal([]byte(existing.GetMetadata()), &existingMetadata); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		users, err := nk.UsersGetId(p.Context, []string{ownerID})
+		if err != nil {
+			return nil, err
+		}
+		if len(users) > 0 {
+			username = users[0].GetUsername()
+		}
+	}
+
+	metadata := existingMetadata
+	if _, ok := p.Args["metadata"].(string); ok {
+		if metadata, err = jsonObjectArg(p, "metadata"); err != nil {
+			return nil, err
+		}
+	}
+
+	if existing != nil {
+		if err := nk.LeaderboardRecordDelete(p.Context, id, ownerID); err != nil {
+			return nil, err
+		}
+	}
+
+	record, err := write(p.Context, id, ownerID, username, p.Args["score"].(int64), p.Args["subscore"].(int64), metadata)
+	if err != nil {
+		if existing == nil {
+			return nil, err
+		}
+		// Written to an empty record, the old score is kept as is by any operator
+		if _, restoreErr := write(p.Context, id, ownerID, username, existing.GetScore(), existing.GetSubscore(), existingMetadata); restoreErr != nil {
+			return nil, fmt.Errorf("failed to write the corrected record: %s, and to restore the previous one: %s", err, restoreErr)
+		}
+		return nil, err
+	}
+
+	recordApplied(p, nk, action, leaderboardRecordTarget(id, ownerID), existing, record)
+
+	return record, nil
+}
+
+// limitArg reads the `limit` argument of a list which is not paged as a connection
+func limitArg(p graphql.ResolveParams) (int, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit < 1 || limit > maxPageSize {
+		return 0, fmt.Errorf("`limit` must be between 1 and %d", maxPageSize)
+	}
+	return limit, nil
+}
+
+func leaderboardRecordTarget(id, ownerID string) string {
+	return fmt.Sprintf("leaderboard:%s/%s", id, ownerID)
+}
+
+func jsonObjectArg(p graphql.ResolveParams, name string) (map[string]interface{}, error) {
+	value := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(p.Args[name].(string)), &value); err != nil {
+		return nil, fmt.Errorf("`%s` must be a json object: %s", name, err)
+	}
+	return value, nil
+}
+
+// unixArg returns a DateTime argument as unix seconds, 0 when omitted
+func unixArg(p graphql.ResolveParams, name string) int {
+	if t, ok := p.Args[name].(time.Time); ok {
+		return int(t.Unix())
+	}
+	if t, ok := p.Args[name].(*time.Time); ok && t != nil {
+		return int(t.Unix())
+	}
+	return 0
+}
+
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
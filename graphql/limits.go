@@ -0,0 +1,280 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// QueryLimits protects the server from abusive queries, a zero value disables a limit
+type QueryLimits struct {
+	// MaxDepth is the deepest level of nested selections an operation may have
+	MaxDepth int
+	// MaxComplexity is the highest cost an operation may have, where every field costs one and the cost of a field's
+	// selections is multiplied by its `first` or `limit` argument, defaults included, by the length of the list it is
+	// given for lists such as `usersById`, or else by unboundedListSize for a list
+	MaxComplexity int
+}
+
+const (
+	// unboundedListSize is the number of items assumed of lists that nothing in the operation bounds
+	unboundedListSize = 50
+)
+
+var (
+	// Limits are applied to every operation executed by the graphql RPC
+	Limits = QueryLimits{
+		MaxDepth:      12,
+		MaxComplexity: 5000,
+	}
+)
+
+type limitsWalker struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	// defaults are the default values of the variables the operation declares
+	defaults map[string]ast.Value
+}
+
+// checkLimits measures the operation of a validated document that will be executed against schema and returns an
+// error if it exceeds limits
+func checkLimits(limits QueryLimits, schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) error {
+
+	if limits.MaxDepth <= 0 && limits.MaxComplexity <= 0 {
+		return nil
+	}
+
+	operation, depth, complexity := measureOperation(schema, doc, operationName, variables)
+
+	// The executor reports missing or ambiguous operations
+	if operation == nil {
+		return nil
+	}
+
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return gqlerrors.NewLocatedError(fmt.Sprintf("operation depth %d exceeds the maximum depth of %d", depth, limits.MaxDepth), []ast.Node{operation})
+	}
+
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return gqlerrors.NewLocatedError(fmt.Sprintf("operation complexity %d exceeds the maximum complexity of %d", complexity, limits.MaxComplexity), []ast.Node{operation})
+	}
+
+	return nil
+}
+
+// measureOperation returns the operation of doc that will be executed along with its depth and complexity, or nil if
+// there is no single operation to execute
+func measureOperation(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) (*ast.OperationDefinition, int, int) {
+
+	w := &limitsWalker{
+		schema:    schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+		defaults:  map[string]ast.Value{},
+	}
+
+	var operation *ast.OperationDefinition
+	operations := 0
+
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			w.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			operations++
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+
+	if operation == nil || (operationName == "" && operations > 1) {
+		return nil, 0, 0
+	}
+
+	for _, def := range operation.VariableDefinitions {
+		if def.DefaultValue != nil {
+			w.defaults[def.Variable.Name.Value] = def.DefaultValue
+		}
+	}
+
+	var root graphql.Type
+	switch operation.Operation {
+	case ast.OperationTypeQuery:
+		root = schema.QueryType()
+	case ast.OperationTypeMutation:
+		if t := schema.MutationType(); t != nil {
+			root = t
+		}
+	case ast.OperationTypeSubscription:
+		if t := schema.SubscriptionType(); t != nil {
+			root = t
+		}
+	}
+
+	depth, complexity := w.measure(root, operation.SelectionSet, false)
+
+	return operation, depth, complexity
+}
+
+// measure measures the selections of set made on parent, which is nil where the type is unknown. Lists selected on a
+// parent that is bounded, as the edges of a connection are by its `first` argument, are not multiplied again.
+func (w *limitsWalker) measure(parent graphql.Type, set *ast.SelectionSet, bounded bool) (depth int, complexity int) {
+
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+
+		var d, c int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			// Introspection is bounded by the schema itself
+			if selection.Name.Value == "__schema" || selection.Name.Value == "__type" {
+				continue
+			}
+			def := fieldDefinition(parent, selection.Name.Value)
+			var child graphql.Type
+			if def != nil {
+				child, _ = graphql.GetNamed(def.Type).(graphql.Type)
+			}
+			n, paged := w.multiplier(def, selection, bounded)
+			d, c = w.measure(child, selection.SelectionSet, paged && !isList(def.Type))
+			d, c = d+1, 1+c*n
+		case *ast.InlineFragment:
+			d, c = w.measure(w.typeCondition(parent, selection.TypeCondition), selection.SelectionSet, bounded)
+		case *ast.FragmentSpread:
+			if fragment, has := w.fragments[selection.Name.Value]; has {
+				d, c = w.measure(w.typeCondition(parent, fragment.TypeCondition), fragment.SelectionSet, bounded)
+			}
+		}
+
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+func (w *limitsWalker) typeCondition(parent graphql.Type, condition *ast.Named) graphql.Type {
+	if condition == nil {
+		return parent
+	}
+	if t := w.schema.Type(condition.Name.Value); t != nil {
+		return t
+	}
+	return nil
+}
+
+func fieldDefinition(parent graphql.Type, name string) *graphql.FieldDefinition {
+	switch parent := parent.(type) {
+	case *graphql.Object:
+		if parent != nil {
+			return parent.Fields()[name]
+		}
+	case *graphql.Interface:
+		if parent != nil {
+			return parent.Fields()[name]
+		}
+	}
+	return nil
+}
+
+// multiplier returns the number of items the selections of field are resolved for, and whether they are paged by
+// an argument, taking the effective value of its `first` or `limit` argument, as given, as the default of the
+// variable given or as the default of the argument
+func (w *limitsWalker) multiplier(def *graphql.FieldDefinition, field *ast.Field, bounded bool) (int, bool) {
+
+	for _, name := range []string{"first", "limit"} {
+		n, has := w.argument(def, field, name)
+		if !has {
+			continue
+		}
+		if n > 1 {
+			return n, true
+		}
+		return 1, true
+	}
+
+	if def == nil || bounded || !isList(def.Type) {
+		return 1, false
+	}
+
+	// Lists of the items given, such as `usersById`, are bounded by the length of the list argument
+	for _, arg := range field.Arguments {
+		switch value := arg.Value.(type) {
+		case *ast.ListValue:
+			return len(value.Values), false
+		case *ast.Variable:
+			if values, isList := w.variables[value.Name.Value].([]interface{}); isList {
+				return len(values), false
+			}
+		}
+	}
+
+	return unboundedListSize, false
+}
+
+// argument returns the effective value of the integer argument named, and whether the field has one
+func (w *limitsWalker) argument(def *graphql.FieldDefinition, field *ast.Field, name string) (int, bool) {
+
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != name {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			return intValue(value)
+		case *ast.Variable:
+			if n, has := numberValue(w.variables[value.Name.Value]); has {
+				return n, true
+			}
+			if value, has := w.defaults[value.Name.Value].(*ast.IntValue); has {
+				return intValue(value)
+			}
+		}
+	}
+
+	if def != nil {
+		for _, arg := range def.Args {
+			if arg.Name() == name {
+				return numberValue(arg.DefaultValue)
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func intValue(value *ast.IntValue) (int, bool) {
+	n, err := strconv.Atoi(value.Value)
+	return n, err == nil
+}
+
+func numberValue(value interface{}) (int, bool) {
+	switch n := value.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+func isList(t graphql.Type) bool {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*graphql.List)
+	return ok
+}
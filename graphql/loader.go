@@ -0,0 +1,204 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+const (
+	GRAPHQL_CTX_LOADERS ContextKey = "loaders"
+)
+
+// loadResult is the outcome of loading a key, shared by every field that asked for the key
+type loadResult struct {
+	value interface{}
+	err   error
+}
+
+// loader batches the keys requested by fields on the same level of a query into a single fetch.
+// Resolvers return the thunk given by load, and the executor only calls thunks once every field
+// on the level has been resolved, so the first thunk called fetches the keys of all of them.
+type loader struct {
+	mutex   sync.Mutex
+	fetch   func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error)
+	cache   bool
+	results map[interface{}]*loadResult
+	pending map[interface{}]*loadResult
+	order   []interface{}
+}
+
+// loaders are scoped to the execution of a single request
+type loaders struct {
+	users    *loader
+	accounts *loader
+	storage  *loader
+}
+
+// storageKey identifies a storage object to load
+type storageKey struct {
+	collection string
+	key        string
+	userID     string
+}
+
+func newLoader(cache bool, fetch func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error)) *loader {
+	return &loader{
+		fetch:   fetch,
+		cache:   cache,
+		results: map[interface{}]*loadResult{},
+		pending: map[interface{}]*loadResult{},
+	}
+}
+
+// newLoaders creates the loaders of a request, only caching results across levels if the request cannot change them
+func newLoaders(nk runtime.NakamaModule, cache bool) *loaders {
+	return &loaders{
+		users: newLoader(cache, func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error) {
+			users, err := nk.UsersGetId(ctx, stringKeys(keys))
+			if err != nil {
+				return nil, err
+			}
+			values := map[interface{}]interface{}{}
+			for _, user := range users {
+				values[user.GetId()] = user
+			}
+			return values, nil
+		}),
+		accounts: newLoader(cache, func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error) {
+			accounts, err := nk.AccountsGetId(ctx, stringKeys(keys))
+			if err != nil {
+				return nil, err
+			}
+			values := map[interface{}]interface{}{}
+			for _, account := range accounts {
+				values[account.GetUser().GetId()] = account
+			}
+			return values, nil
+		}),
+		storage: newLoader(cache, func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error) {
+			reads := []*runtime.StorageRead{}
+			for _, key := range keys {
+				k := key.(storageKey)
+				reads = append(reads, &runtime.StorageRead{Collection: k.collection, Key: k.key, UserID: k.userID})
+			}
+			objs, err := nk.StorageRead(ctx, reads)
+			if err != nil {
+				return nil, err
+			}
+			values := map[interface{}]interface{}{}
+			for _, obj := range objs {
+				values[storageKey{obj.GetCollection(), obj.GetKey(), obj.GetUserId()}] = obj
+			}
+			return values, nil
+		}),
+	}
+}
+
+// load queues key to be fetched with the other keys of its level, the returned thunk gives nil if nothing was found
+func (l *loader) load(ctx context.Context, key interface{}) func() (interface{}, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	result, has := l.results[key]
+	if !has {
+		if result, has = l.pending[key]; !has {
+			result = &loadResult{}
+			l.pending[key] = result
+			l.order = append(l.order, key)
+		}
+	}
+
+	return func() (interface{}, error) {
+		l.dispatch(ctx)
+		return result.value, result.err
+	}
+}
+
+// dispatch fetches every pending key at once
+func (l *loader) dispatch(ctx context.Context) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(l.order) == 0 {
+		return
+	}
+
+	values, err := l.fetch(ctx, l.order)
+	for _, key := range l.order {
+		result := l.pending[key]
+		result.value, result.err = values[key], err
+		if l.cache {
+			l.results[key] = result
+		}
+	}
+
+	l.pending, l.order = map[interface{}]*loadResult{}, nil
+}
+
+func stringKeys(keys []interface{}) []string {
+	strs := make([]string, len(keys))
+	for i, key := range keys {
+		strs[i] = key.(string)
+	}
+	return strs
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	if l, ok := ctx.Value(GRAPHQL_CTX_LOADERS).(*loaders); ok {
+		return l
+	}
+	// Resolvers executed outside of Execute get loaders of their own, which batch nothing
+	return newLoaders(ctx.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule), false)
+}
+
+// loadUser resolves to the user with userID, or nil if there is none
+func loadUser(p graphql.ResolveParams, userID string) (interface{}, error) {
+	return loadersFromContext(p.Context).users.load(p.Context, userID), nil
+}
+
+// loadAccount resolves to the account of userID, failing if there is none
+func loadAccount(p graphql.ResolveParams, userID string) (interface{}, error) {
+	thunk := loadersFromContext(p.Context).accounts.load(p.Context, userID)
+	return func() (interface{}, error) {
+		acc, err := thunk()
+		if err == nil && acc == nil {
+			err = fmt.Errorf("no account for user `%s`", userID)
+		}
+		return acc, err
+	}, nil
+}
+
+// loadStorageObject resolves to a storage object, or nil if there is none
+func loadStorageObject(p graphql.ResolveParams, collection, key, userID string) func() (interface{}, error) {
+	return loadersFromContext(p.Context).storage.load(p.Context, storageKey{collection, key, userID})
+}
+
+// isMutation tells whether the operation a request executes is a mutation
+func isMutation(doc *ast.Document, operationName string) bool {
+	op := operation(doc, operationName)
+	return op != nil && op.Operation == ast.OperationTypeMutation
+}
+
+// operation returns the operation a request executes, nil if there is none by that name
+func operation(doc *ast.Document, operationName string) *ast.OperationDefinition {
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok {
+			if operationName == "" || (op.Name != nil && op.Name.Value == operationName) {
+				return op
+			}
+		}
+	}
+	return nil
+}
+
+// storageObjectValue returns the typed nil-able storage object of a loaded value
+func storageObjectValue(value interface{}) *api.StorageObject {
+	obj, _ := value.(*api.StorageObject)
+	return obj
+}
@@ -0,0 +1,329 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+// Stream modes as numbered by Nakama's tracker
+const (
+	streamModeNotifications = iota
+	streamModeStatus
+	streamModeChannel
+	streamModeGroup
+	streamModeDM
+	streamModeMatchRelayed
+	streamModeMatchAuthoritative
+)
+
+// stream identifies a Nakama presence stream
+type stream struct {
+	mode       int
+	subject    string
+	subcontext string
+	label      string
+}
+
+func (s stream) String() string {
+	return fmt.Sprintf("stream:%d:%s:%s:%s", s.mode, s.subject, s.subcontext, s.label)
+}
+
+var (
+	streamModeType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "StreamMode",
+		Description: "The kind of a presence stream.",
+		Values: graphql.EnumValueConfigMap{
+			"NOTIFICATIONS":       &graphql.EnumValueConfig{Value: streamModeNotifications},
+			"STATUS":              &graphql.EnumValueConfig{Value: streamModeStatus},
+			"CHANNEL":             &graphql.EnumValueConfig{Value: streamModeChannel},
+			"GROUP":               &graphql.EnumValueConfig{Value: streamModeGroup},
+			"DM":                  &graphql.EnumValueConfig{Value: streamModeDM},
+			"MATCH_RELAYED":       &graphql.EnumValueConfig{Value: streamModeMatchRelayed},
+			"MATCH_AUTHORITATIVE": &graphql.EnumValueConfig{Value: streamModeMatchAuthoritative},
+		},
+	})
+
+	presenceType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Presence",
+		Description: "A session of a user on a stream.",
+		Fields: graphql.Fields{
+			"userId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(runtime.Presence).GetUserId(), nil
+				},
+			},
+			"sessionId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(runtime.Presence).GetSessionId(), nil
+				},
+			},
+			"nodeId": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The Nakama node the session is connected to.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(runtime.Presence).GetNodeId(), nil
+				},
+			},
+			"username": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(runtime.Presence).GetUsername(), nil
+				},
+			},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(runtime.Presence).GetStatus(), nil
+				},
+			},
+			"hidden": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether the presence is hidden from other presences on the stream.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(runtime.Presence).GetHidden(), nil
+				},
+			},
+			"persistence": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether messages sent by the presence are persisted.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(runtime.Presence).GetPersistence(), nil
+				},
+			},
+			"user": &graphql.Field{
+				Type:        userType,
+				Description: "The user of the session.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadUser(p, p.Source.(runtime.Presence).GetUserId())
+				},
+			},
+		},
+	})
+
+	matchType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Match",
+		Description: "A running match.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Match).GetMatchId(), nil
+				},
+			},
+			"authoritative": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether the match is run by the server rather than relayed between clients.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Match).GetAuthoritative(), nil
+				},
+			},
+			"label": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The match label, null if it has none.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					label := p.Source.(*api.Match).GetLabel()
+					if label == nil {
+						return nil, nil
+					}
+					return label.GetValue(), nil
+				},
+			},
+			"size": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of users in the match.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.Match).GetSize()), nil
+				},
+			},
+			"presences": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(presenceType))),
+				Description: "The sessions in the match.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return streamUserList(p, matchStream(p.Source.(*api.Match)), true)
+				},
+			},
+		},
+	})
+
+	streamArgs = graphql.FieldConfigArgument{
+		"mode": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(streamModeType),
+		},
+		"subject": &graphql.ArgumentConfig{
+			Type:         graphql.String,
+			DefaultValue: "",
+		},
+		"subcontext": &graphql.ArgumentConfig{
+			Type:         graphql.String,
+			DefaultValue: "",
+		},
+		"label": &graphql.ArgumentConfig{
+			Type:         graphql.String,
+			DefaultValue: "",
+		},
+	}
+
+	matchesField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(matchType))),
+		Description: "Lists running matches.",
+		Args: graphql.FieldConfigArgument{
+			"limit": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: defaultPageSize,
+				Description:  fmt.Sprintf("The number of matches to return, up to %d.", maxPageSize),
+			},
+			"authoritative": &graphql.ArgumentConfig{
+				Type:        graphql.Boolean,
+				Description: "Only return authoritative matches when true, or relayed matches when false, both are returned when omitted.",
+			},
+			"label": &graphql.ArgumentConfig{
+				Type:        graphql.String,
+				Description: "Only return matches with this exact label.",
+			},
+			"minSize": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 0,
+			},
+			"maxSize": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 100,
+			},
+			"query": &graphql.ArgumentConfig{
+				Type:        graphql.String,
+				Description: "A query over the labels of authoritative matches.",
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+
+			limit := p.Args["limit"].(int)
+			if limit < 1 || limit > maxPageSize {
+				return nil, fmt.Errorf("`limit` must be between 1 and %d", maxPageSize)
+			}
+
+			label, _ := p.Args["label"].(string)
+			query, _ := p.Args["query"].(string)
+			minSize, maxSize := p.Args["minSize"].(int), p.Args["maxSize"].(int)
+
+			kinds := []bool{true, false}
+			if authoritative, ok := p.Args["authoritative"].(bool); ok {
+				kinds = []bool{authoritative}
+			}
+
+			matches := []*api.Match{}
+			for _, authoritative := range kinds {
+				if len(matches) == limit {
+					break
+				}
+				list, err := nk.MatchList(p.Context, limit-len(matches), authoritative, label, minSize, maxSize, query)
+				if err != nil {
+					return nil, err
+				}
+				matches = append(matches, list...)
+			}
+
+			return matches, nil
+		},
+	}
+
+	streamPresencesField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(presenceType))),
+		Description: "Lists the presences on a stream.",
+		Args: withArgs(streamArgs, graphql.FieldConfigArgument{
+			"includeHidden": &graphql.ArgumentConfig{
+				Type:         graphql.Boolean,
+				DefaultValue: true,
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return streamUserList(p, streamArg(p), p.Args["includeHidden"].(bool))
+		},
+	}
+
+	kickFromStreamField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Removes a session of a user from a stream.",
+		Args: withArgs(streamArgs, graphql.FieldConfigArgument{
+			"userId": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"sessionId": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			s := streamArg(p)
+			userID, sessionID := p.Args["userId"].(string), p.Args["sessionId"].(string)
+
+			if err := nk.StreamUserLeave(uint8(s.mode), s.subject, s.subcontext, s.label, userID, sessionID); err != nil {
+				return nil, err
+			}
+
+			recordApplied(p, nk, "kickFromStream", s.String(), map[string]string{"userId": userID, "sessionId": sessionID}, nil)
+
+			return true, nil
+		},
+	}
+
+	// sendToStreamField is the fallback for reaching a running match, as the runtime of Nakama 2.3.2 has no api to
+	// signal a match handler. Data sent to a match's stream reaches its presences, not the handler itself.
+	sendToStreamField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Sends data to every presence on a stream, such as the presences of a match. Nakama 2.3.2 cannot signal a match handler, so this reaches the players of a match rather than its handler.",
+		Args: withArgs(streamArgs, graphql.FieldConfigArgument{
+			"data": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			s := streamArg(p)
+			data := p.Args["data"].(string)
+
+			if err := nk.StreamSend(uint8(s.mode), s.subject, s.subcontext, s.label, data); err != nil {
+				return nil, err
+			}
+
+			recordApplied(p, nk, "sendToStream", s.String(), nil, data)
+
+			return true, nil
+		},
+	}
+)
+
+// matchStream returns the stream of the presences in a match, its id is made of the stream's subject and label
+func matchStream(match *api.Match) stream {
+	parts := strings.SplitN(match.GetMatchId(), ".", 2)
+	s := stream{mode: streamModeMatchRelayed, subject: parts[0]}
+	if match.GetAuthoritative() {
+		s.mode = streamModeMatchAuthoritative
+	}
+	if len(parts) > 1 {
+		s.label = parts[1]
+	}
+	return s
+}
+
+func streamArg(p graphql.ResolveParams) stream {
+	return stream{
+		mode:       p.Args["mode"].(int),
+		subject:    p.Args["subject"].(string),
+		subcontext: p.Args["subcontext"].(string),
+		label:      p.Args["label"].(string),
+	}
+}
+
+func streamUserList(p graphql.ResolveParams, s stream, includeHidden bool) ([]runtime.Presence, error) {
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	return nk.StreamUserList(uint8(s.mode), s.subject, s.subcontext, s.label, includeHidden, true)
+}
@@ -0,0 +1,275 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/api"
+
+	"github.com/mastern2k3/poseidon/storage"
+)
+
+var (
+	modelsMutex sync.Mutex
+
+	// modelCollections maps collections to the type of the model stored in them
+	modelCollections = map[string]reflect.Type{}
+	// modelObjects maps struct types to the objects reflected from them, names to the struct types using them
+	modelObjects = map[reflect.Type]*graphql.Object{}
+	modelNames   = map[string]reflect.Type{}
+
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// RegisterModels adds a GraphQL object reflected from the model of each accessor, named after the model's struct type,
+// exposed through the `model` field of storage objects in the accessor's collection.
+// Fields are named after their json names, so the objects match the stored values.
+// Must be called before RegisterGraphQL.
+func RegisterModels(accessors ...storage.Accessor) error {
+	if err := extensible(); err != nil {
+		return err
+	}
+
+	modelsMutex.Lock()
+	defer modelsMutex.Unlock()
+
+	for _, acc := range accessors {
+		if _, err := registerModel(acc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// registerModel reflects the model of an accessor into an object, called with modelsMutex held
+func registerModel(acc storage.Accessor) (*graphql.Object, error) {
+
+	t := reflect.TypeOf(acc.NewModel())
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("the model of collection `%s` must be a struct, got `%v`", acc.Collection(), t)
+	}
+
+	if existing, has := modelCollections[acc.Collection()]; has && existing != t {
+		return nil, fmt.Errorf("collection `%s` is already registered with model `%v`", acc.Collection(), existing)
+	}
+
+	obj, err := modelObject(t, "")
+	if err != nil {
+		return nil, err
+	}
+
+	modelCollections[acc.Collection()] = t
+
+	return obj, nil
+}
+
+// modelOutput returns the GraphQL type reflected from the type of a model's field, or nil if it is not representable
+func modelOutput(t reflect.Type, name string) (graphql.Output, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return graphql.DateTime, nil
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		// Encoded by encoding/json as a base64 string
+		return graphql.String, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return graphql.Boolean, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint:
+		return graphql.Int, nil
+	case reflect.Int64, reflect.Uint64:
+		return longType, nil
+	case reflect.Float32, reflect.Float64:
+		return graphql.Float, nil
+	case reflect.String:
+		return graphql.String, nil
+	case reflect.Map, reflect.Interface:
+		return jsonType, nil
+	case reflect.Slice, reflect.Array:
+		elem, err := modelOutput(t.Elem(), name)
+		if err != nil || elem == nil {
+			return nil, err
+		}
+		return graphql.NewList(elem), nil
+	case reflect.Struct:
+		return modelObject(t, name)
+	}
+	return nil, nil
+}
+
+// modelObject returns the object reflected from a struct type, named after the type or name if the struct is anonymous
+func modelObject(t reflect.Type, name string) (*graphql.Object, error) {
+
+	if obj, has := modelObjects[t]; has {
+		return obj, nil
+	}
+
+	if t.Name() != "" {
+		name = t.Name()
+	}
+	if existing, has := modelNames[name]; has {
+		return nil, fmt.Errorf("model name `%s` is used by both `%v` and `%v`", name, existing, t)
+	}
+
+	obj := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name,
+		Description: fmt.Sprintf("Reflected from the `%v` model.", t),
+		Fields:      graphql.Fields{},
+	})
+	if err := obj.Error(); err != nil {
+		return nil, err
+	}
+
+	// Registered before its fields so that recursive models refer back to it
+	modelObjects[t], modelNames[name] = obj, t
+
+	err := addModelFields(obj, t, nil)
+	if err == nil && len(obj.Fields()) == 0 {
+		err = fmt.Errorf("model `%v` has no exported fields", t)
+	}
+	if err != nil {
+		delete(modelObjects, t)
+		delete(modelNames, name)
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// addModelFields adds the fields of struct type t to obj, flattening embedded structs the way encoding/json does
+func addModelFields(obj *graphql.Object, t reflect.Type, index []int) error {
+	for i := 0; i < t.NumField(); i++ {
+
+		f := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		name, tagged := f.Name, false
+		if tag := f.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+				name, tagged = tagName, true
+			}
+		}
+
+		if f.Anonymous && !tagged && f.Type.Kind() == reflect.Struct {
+			if err := addModelFields(obj, f.Type, fieldIndex); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		output, err := modelOutput(f.Type, obj.Name()+f.Name)
+		if err != nil {
+			return err
+		}
+		if output == nil {
+			continue
+		}
+
+		obj.AddFieldConfig(name, &graphql.Field{
+			Type:    output,
+			Resolve: modelFieldResolver(fieldIndex),
+		})
+	}
+	return obj.Error()
+}
+
+func modelFieldResolver(index []int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v := reflect.ValueOf(p.Source)
+		for _, i := range index {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return nil, nil
+				}
+				v = v.Elem()
+			}
+			v = v.Field(i)
+		}
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, nil
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Int64:
+			return v.Int(), nil
+		case reflect.Uint64:
+			return int64(v.Uint()), nil
+		}
+		return v.Interface(), nil
+	}
+}
+
+// storageModelField returns the `model` field of storage objects, nil if no models were registered
+func storageModelField() *graphql.Field {
+	modelsMutex.Lock()
+	defer modelsMutex.Unlock()
+
+	if len(modelCollections) == 0 {
+		return nil
+	}
+
+	objects := []*graphql.Object{}
+	for _, t := range modelCollections {
+		obj := modelObjects[t]
+		if !containsObject(objects, obj) {
+			objects = append(objects, obj)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name() < objects[j].Name() })
+
+	modelType := graphql.NewUnion(graphql.UnionConfig{
+		Name:        "StorageModel",
+		Description: "The value of a storage object in a collection with a registered model.",
+		Types:       objects,
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return modelObjects[reflect.TypeOf(p.Value).Elem()]
+		},
+	})
+
+	return &graphql.Field{
+		Type:        modelType,
+		Description: "The value decoded into the model of its collection, null if the collection has no registered model.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			obj := p.Source.(*api.StorageObject)
+			t, has := modelCollections[obj.GetCollection()]
+			if !has {
+				return nil, nil
+			}
+			model := reflect.New(t).Interface()
+			if err := json.Unmarshal([]byte(obj.GetValue()), model); err != nil {
+				return nil, err
+			}
+			return model, nil
+		},
+	}
+}
+
+func containsObject(objects []*graphql.Object, obj *graphql.Object) bool {
+	for _, o := range objects {
+		if o == obj {
+			return true
+		}
+	}
+	return false
+}
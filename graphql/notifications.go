@@ -0,0 +1,265 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+var (
+	notificationType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Notification",
+		Description: "An in-app notification sent to a user.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Notification).GetId(), nil
+				},
+			},
+			"subject": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Notification).GetSubject(), nil
+				},
+			},
+			"content": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The content of the notification, a json object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Notification).GetContent(), nil
+				},
+			},
+			"code": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The code the client uses to tell notifications apart, negative codes are reserved by Nakama.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.Notification).GetCode()), nil
+				},
+			},
+			"senderId": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The user who sent the notification, null if it was sent by the server.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalSender(p.Source.(*api.Notification).GetSenderId()), nil
+				},
+			},
+			"createTime": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalTime(p.Source.(*api.Notification).GetCreateTime()), nil
+				},
+			},
+		},
+	})
+
+	notificationConnectionType = newConnectionType(notificationType, "A page of notifications.")
+
+	notificationArgs = graphql.FieldConfigArgument{
+		"subject": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"content": &graphql.ArgumentConfig{
+			Type:         graphql.String,
+			DefaultValue: "{}",
+			Description:  "The content of the notification, a json object.",
+		},
+		"code": &graphql.ArgumentConfig{
+			Type:        graphql.NewNonNull(graphql.Int),
+			Description: "The code the client uses to tell notifications apart, must be positive.",
+		},
+		"persistent": &graphql.ArgumentConfig{
+			Type:         graphql.Boolean,
+			DefaultValue: true,
+			Description:  "Whether the notification is kept in the user's history, otherwise it is only delivered to online users.",
+		},
+		"senderId": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "The user to send the notification as, the server when omitted.",
+		},
+	}
+
+	sendNotificationField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Int),
+		Description: "Sends a notification to each of the given users, returning the number of notifications sent.",
+		Args: withArgs(notificationArgs, graphql.FieldConfigArgument{
+			"userIds": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			userIDs := []string{}
+			for _, id := range p.Args["userIds"].([]interface{}) {
+				userIDs = append(userIDs, id.(string))
+			}
+			return sendNotifications(p, "users:"+strings.Join(userIDs, ","), userIDs)
+		},
+	}
+
+	sendGroupNotificationField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Int),
+		Description: "Sends a notification to each member of a group, returning the number of notifications sent.",
+		Args: withArgs(notificationArgs, graphql.FieldConfigArgument{
+			"groupId": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			groupID := p.Args["groupId"].(string)
+
+			members, err := nk.GroupUsersList(p.Context, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			userIDs := []string{}
+			for _, member := range members {
+				if int(member.GetState().GetValue()) != groupRoleJoinRequest {
+					userIDs = append(userIDs, member.GetUser().GetId())
+				}
+			}
+
+			return sendNotifications(p, "group:"+groupID, userIDs)
+		},
+	}
+)
+
+// sendNotifications sends the notification described by p's arguments to every user in userIDs
+func sendNotifications(p graphql.ResolveParams, target string, userIDs []string) (interface{}, error) {
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+
+	content, err := jsonObjectArg(p, "content")
+	if err != nil {
+		return nil, err
+	}
+
+	subject, code := p.Args["subject"].(string), p.Args["code"].(int)
+	if code < 1 {
+		return nil, fmt.Errorf("`code` must be positive")
+	}
+
+	sender, _ := p.Args["senderId"].(string)
+	persistent := p.Args["persistent"].(bool)
+
+	notifications := []*runtime.NotificationSend{}
+	for _, userID := range userIDs {
+		notifications = append(notifications, &runtime.NotificationSend{
+			UserID:     userID,
+			Subject:    subject,
+			Content:    content,
+			Code:       code,
+			Sender:     sender,
+			Persistent: persistent,
+		})
+	}
+
+	if len(notifications) > 0 {
+		if err := nk.NotificationsSend(p.Context, notifications); err != nil {
+			return nil, err
+		}
+	}
+
+	recordApplied(p, nk, "sendNotification", target, nil, map[string]interface{}{
+		"subject":    subject,
+		"content":    json.RawMessage(p.Args["content"].(string)),
+		"code":       code,
+		"senderId":   sender,
+		"persistent": persistent,
+	})
+
+	return len(notifications), nil
+}
+
+// resolveNotificationConnection pages through the persisted notifications of the user in p.Source, newest first,
+// querying Nakama's notification table as the runtime can only list notifications of the calling session
+func resolveNotificationConnection(p graphql.ResolveParams) (interface{}, error) {
+
+	db, err := dbFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	first, after, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT id, subject, content, code, sender_id, create_time FROM notification WHERE user_id = $1"
+	args := []interface{}{p.Source.(*api.User).GetId()}
+
+	if after != "" {
+		createTime, id, err := decodeNotificationCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		query += " AND (create_time, id) < ($2, $3)"
+		args = append(args, createTime, id)
+	}
+
+	query += fmt.Sprintf(" ORDER BY create_time DESC, id DESC LIMIT $%d", len(args)+1)
+	args = append(args, first+1)
+
+	rows, err := db.QueryContext(p.Context, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conn := &connection{}
+
+	for rows.Next() {
+		if len(conn.edges) == first {
+			conn.hasNextPage = true
+			break
+		}
+		var n api.Notification
+		var createTime time.Time
+		if err := rows.Scan(&n.Id, &n.Subject, &n.Content, &n.Code, &n.SenderId, &createTime); err != nil {
+			return nil, err
+		}
+		if n.CreateTime, err = ptypes.TimestampProto(createTime); err != nil {
+			return nil, err
+		}
+		n.Persistent = true
+		cursor := base64.RawURLEncoding.EncodeToString([]byte(createTime.UTC().Format(time.RFC3339Nano) + "/" + n.Id))
+		conn.edges = append(conn.edges, edge{cursor, &n})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func decodeNotificationCursor(cursor string) (time.Time, string, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("malformed cursor `%s`", cursor)
+	}
+	parts := strings.SplitN(string(bytes), "/", 2)
+	if len(parts) != 2 {
+		return time.Time{}, "", fmt.Errorf("malformed cursor `%s`", cursor)
+	}
+	createTime, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("malformed cursor `%s`", cursor)
+	}
+	return createTime, parts[1], nil
+}
+
+func optionalSender(senderID string) interface{} {
+	if senderID == "" || senderID == systemUserID {
+		return nil
+	}
+	return senderID
+}
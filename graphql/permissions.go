@@ -0,0 +1,71 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	GRAPHQL_CTX_ALLOWED_MUTATIONS ContextKey = "allowed_mutations"
+
+	// AllMutations allows every root mutation when listed among the allowed mutations
+	AllMutations = "*"
+)
+
+// WithAllowedMutations returns a context only allowing the root mutations named to be executed, or all of them if
+// AllMutations is among them. Requests executed without such a context are not restricted, such as those made
+// through the graphql RPC, which only answers server to server calls made with Nakama's http key.
+func WithAllowedMutations(ctx context.Context, names []string) context.Context {
+	allowed := map[string]bool{}
+	for _, name := range names {
+		allowed[name] = true
+	}
+	return context.WithValue(ctx, GRAPHQL_CTX_ALLOWED_MUTATIONS, allowed)
+}
+
+// checkMutations fails if the operation is a mutation selecting a root field which ctx does not allow
+func checkMutations(ctx context.Context, doc *ast.Document, op *ast.OperationDefinition) error {
+
+	allowed, restricted := ctx.Value(GRAPHQL_CTX_ALLOWED_MUTATIONS).(map[string]bool)
+	if !restricted || allowed[AllMutations] || op == nil || op.Operation != ast.OperationTypeMutation {
+		return nil
+	}
+
+	for _, name := range rootFieldNames(doc, op.SelectionSet, map[string]bool{}) {
+		if !allowed[name] {
+			return fmt.Errorf("mutation `%s` is not permitted", name)
+		}
+	}
+
+	return nil
+}
+
+// rootFieldNames lists the fields selected by a selection set, including those selected through fragments
+func rootFieldNames(doc *ast.Document, set *ast.SelectionSet, visited map[string]bool) []string {
+	names := []string{}
+	if set == nil {
+		return names
+	}
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			names = append(names, selection.Name.Value)
+		case *ast.InlineFragment:
+			names = append(names, rootFieldNames(doc, selection.SelectionSet, visited)...)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+			for _, def := range doc.Definitions {
+				if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name.Value == name {
+					names = append(names, rootFieldNames(doc, fragment.SelectionSet, visited)...)
+				}
+			}
+		}
+	}
+	return names
+}
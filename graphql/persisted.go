@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// MaxPersistedQueries bounds the number of queries persisted by clients, later ones are executed without being kept
+var MaxPersistedQueries = 1000
+
+// RequestExtensions are the protocol extensions a request may use
+type RequestExtensions struct {
+	PersistedQuery *PersistedQuery `json:"persistedQuery,omitempty"`
+}
+
+// PersistedQuery refers to a query by its hash, as in Apollo's automatic persisted queries
+type PersistedQuery struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+var (
+	persistedMutex sync.RWMutex
+	// persistedQueries maps the sha256 hashes of queries to the queries
+	persistedQueries = map[string]string{}
+	// registeredQueries counts the queries of persistedQueries registered by the server, which do not count toward the limit
+	registeredQueries = 0
+)
+
+// RegisterPersistedQuery lets clients execute query by sending only the hash it returns
+func RegisterPersistedQuery(query string) string {
+	hash := queryHash(query)
+	persistedMutex.Lock()
+	defer persistedMutex.Unlock()
+	if _, has := persistedQueries[hash]; !has {
+		persistedQueries[hash] = query
+		registeredQueries++
+	}
+	return hash
+}
+
+// resolvePersistedQuery fills in the query of a request which only sends its hash,
+// and persists the query of a request sending both
+func resolvePersistedQuery(request *GraphQLRequest) *GraphQLResponse {
+
+	if request.Extensions == nil || request.Extensions.PersistedQuery == nil {
+		return nil
+	}
+
+	persisted := request.Extensions.PersistedQuery
+	if persisted.Version != 1 {
+		return persistedQueryError("PersistedQueryNotSupported", "PERSISTED_QUERY_NOT_SUPPORTED")
+	}
+
+	if request.Query == "" {
+		persistedMutex.RLock()
+		query, has := persistedQueries[persisted.Sha256Hash]
+		persistedMutex.RUnlock()
+		if !has {
+			return persistedQueryError("PersistedQueryNotFound", "PERSISTED_QUERY_NOT_FOUND")
+		}
+		request.Query = query
+		return nil
+	}
+
+	if queryHash(request.Query) != persisted.Sha256Hash {
+		return persistedQueryError("provided sha does not match query", "BAD_PERSISTED_QUERY_HASH")
+	}
+
+	persistedMutex.Lock()
+	defer persistedMutex.Unlock()
+	if len(persistedQueries)-registeredQueries < MaxPersistedQueries {
+		persistedQueries[persisted.Sha256Hash] = request.Query
+	}
+
+	return nil
+}
+
+// persistedQueryError is a failure named as Apollo clients expect it, to know whether to retry with the full query
+func persistedQueryError(message string, code string) *GraphQLResponse {
+	return &GraphQLResponse{Errors: []GraphQLError{{Message: message, Extensions: map[string]interface{}{"code": code}}}}
+}
+
+func queryHash(query string) string {
+	hash := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
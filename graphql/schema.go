@@ -0,0 +1,217 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Extension is a set of additions a module makes to the GraphQL schema
+type Extension struct {
+	// Module names the extension in conflict errors
+	Module string
+	// Types are added to the schema even if no field refers to them, such as the implementations of an interface
+	Types         []graphql.Type
+	Queries       graphql.Fields
+	Mutations     graphql.Fields
+	Subscriptions Subscriptions
+}
+
+var (
+	// ErrSchemaFrozen is returned when the schema is extended after RegisterGraphQL built it
+	ErrSchemaFrozen = errors.New("the graphql schema was already built by RegisterGraphQL, extensions must be registered before it")
+
+	schemaMutex  sync.Mutex
+	schemaFrozen bool
+
+	// fieldOwners maps the root fields added by extensions, such as `Query.liveParams`, to their modules
+	fieldOwners        = map[string]string{}
+	extensionTypes     = map[string]graphql.Type{}
+	subscriptionFields = mustSubscriptionFields(builtinSubscriptions)
+)
+
+// Extend adds the types and root fields of ext to the schema, failing without adding any of them
+// if a root field or type name is already taken or the schema was already built
+func Extend(ext Extension) error {
+	schemaMutex.Lock()
+	defer schemaMutex.Unlock()
+
+	return extend(ext, nil)
+}
+
+// extend adds ext to the schema like Extend, called with schemaMutex held. When prepare is given it is called once
+// ext is known not to conflict, right before it is added, and ext is not added if prepare fails.
+func extend(ext Extension, prepare func() error) error {
+
+	if schemaFrozen {
+		return fmt.Errorf("cannot extend the schema with module `%s`: %s", ext.Module, ErrSchemaFrozen)
+	}
+
+	subscriptions, err := ext.Subscriptions.fields()
+	if err != nil {
+		return fmt.Errorf("cannot extend the schema with module `%s`: %s", ext.Module, err)
+	}
+
+	roots := []struct {
+		name     string
+		fields   graphql.Fields
+		existing map[string]bool
+		add      func(name string, field *graphql.Field)
+	}{
+		{rootQuery.Name(), ext.Queries, objectFieldNames(rootQuery), rootQuery.AddFieldConfig},
+		{rootMutation.Name(), ext.Mutations, objectFieldNames(rootMutation), rootMutation.AddFieldConfig},
+		{"RootSubscription", subscriptions, fieldNames(subscriptionFields), func(name string, field *graphql.Field) {
+			subscriptionFields[name] = field
+		}},
+	}
+
+	for _, root := range roots {
+		for _, name := range sortedFieldNames(root.fields) {
+			if !root.existing[name] {
+				continue
+			}
+			if owner, has := fieldOwners[root.name+"."+name]; has {
+				return fmt.Errorf("field `%s.%s` of module `%s` is already registered by module `%s`", root.name, name, ext.Module, owner)
+			}
+			return fmt.Errorf("field `%s.%s` of module `%s` is already a built-in field", root.name, name, ext.Module)
+		}
+	}
+
+	for _, t := range ext.Types {
+		if existing, has := extensionTypes[t.Name()]; has && existing != t {
+			return fmt.Errorf("type `%s` of module `%s` is already registered", t.Name(), ext.Module)
+		}
+	}
+
+	if prepare != nil {
+		if err := prepare(); err != nil {
+			return err
+		}
+	}
+
+	for _, root := range roots {
+		for name, field := range root.fields {
+			root.add(name, field)
+			fieldOwners[root.name+"."+name] = ext.Module
+		}
+	}
+
+	for _, t := range ext.Types {
+		extensionTypes[t.Name()] = t
+	}
+
+	return nil
+}
+
+// extensible returns an error if the schema was already built
+func extensible() error {
+	schemaMutex.Lock()
+	defer schemaMutex.Unlock()
+	if schemaFrozen {
+		return ErrSchemaFrozen
+	}
+	return nil
+}
+
+// buildSchema builds the schema out of the built-in fields and every extension, freezing it.
+// Later calls keep the schema already built.
+func buildSchema() error {
+	schemaMutex.Lock()
+	defer schemaMutex.Unlock()
+
+	if schemaFrozen {
+		return nil
+	}
+
+	if field := storageModelField(); field != nil {
+		storageType.AddFieldConfig("model", field)
+	}
+
+	config := graphql.SchemaConfig{Query: rootQuery, Mutation: rootMutation}
+
+	for _, name := range sortedTypeNames(extensionTypes) {
+		config.Types = append(config.Types, extensionTypes[name])
+	}
+
+	config.Subscription = graphql.NewObject(graphql.ObjectConfig{
+		Name:   "RootSubscription",
+		Fields: subscriptionFields,
+	})
+
+	built, err := graphql.NewSchema(config)
+	if err != nil {
+		return err
+	}
+
+	schema, schemaFrozen = built, true
+
+	return nil
+}
+
+// fieldsOf turns the fields defined on obj back into field configs
+func fieldsOf(obj *graphql.Object) graphql.Fields {
+	fields := graphql.Fields{}
+	for name, def := range obj.Fields() {
+		args := graphql.FieldConfigArgument{}
+		for _, arg := range def.Args {
+			args[arg.Name()] = &graphql.ArgumentConfig{
+				Type:         arg.Type,
+				DefaultValue: arg.DefaultValue,
+				Description:  arg.Description(),
+			}
+		}
+		fields[name] = &graphql.Field{
+			Type:              def.Type,
+			Args:              args,
+			Resolve:           def.Resolve,
+			DeprecationReason: def.DeprecationReason,
+			Description:       def.Description,
+		}
+	}
+	return fields
+}
+
+func mustSubscriptionFields(subs Subscriptions) graphql.Fields {
+	fields, err := subs.fields()
+	if err != nil {
+		panic(err)
+	}
+	return fields
+}
+
+func objectFieldNames(obj *graphql.Object) map[string]bool {
+	names := map[string]bool{}
+	for name := range obj.Fields() {
+		names[name] = true
+	}
+	return names
+}
+
+func fieldNames(fields graphql.Fields) map[string]bool {
+	names := map[string]bool{}
+	for name := range fields {
+		names[name] = true
+	}
+	return names
+}
+
+func sortedFieldNames(fields graphql.Fields) []string {
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedTypeNames(types map[string]graphql.Type) []string {
+	names := []string{}
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,733 @@
+package graphql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/audit"
+)
+
+// Edge states as stored by Nakama in the user_edge and group_edge tables
+const (
+	friendStateFriend         = 0
+	friendStateInviteSent     = 1
+	friendStateInviteReceived = 2
+	friendStateBlocked        = 3
+
+	groupRoleSuperadmin  = 0
+	groupRoleAdmin       = 1
+	groupRoleMember      = 2
+	groupRoleJoinRequest = 3
+)
+
+type friend struct {
+	user       *api.User
+	state      int
+	updateTime time.Time
+}
+
+var (
+	friendStateType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "FriendState",
+		Description: "The relationship of a user to another.",
+		Values: graphql.EnumValueConfigMap{
+			"FRIEND":          &graphql.EnumValueConfig{Value: friendStateFriend, Description: "The users are friends."},
+			"INVITE_SENT":     &graphql.EnumValueConfig{Value: friendStateInviteSent, Description: "The user invited the other."},
+			"INVITE_RECEIVED": &graphql.EnumValueConfig{Value: friendStateInviteReceived, Description: "The user was invited by the other."},
+			"BLOCKED":         &graphql.EnumValueConfig{Value: friendStateBlocked, Description: "The user blocked the other."},
+		},
+	})
+
+	groupRoleType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "GroupRole",
+		Description: "The relationship of a user to a group.",
+		Values: graphql.EnumValueConfigMap{
+			"SUPERADMIN":   &graphql.EnumValueConfig{Value: groupRoleSuperadmin},
+			"ADMIN":        &graphql.EnumValueConfig{Value: groupRoleAdmin},
+			"MEMBER":       &graphql.EnumValueConfig{Value: groupRoleMember},
+			"JOIN_REQUEST": &graphql.EnumValueConfig{Value: groupRoleJoinRequest, Description: "The user asked to join and was not accepted yet."},
+		},
+	})
+
+	friendType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Friend",
+		Description: "A user related to another user.",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type:        graphql.NewNonNull(userType),
+				Description: "The related user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*friend).user, nil
+				},
+			},
+			"state": &graphql.Field{
+				Type:        graphql.NewNonNull(friendStateType),
+				Description: "The relationship to the related user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*friend).state, nil
+				},
+			},
+			"updateTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the relationship last changed.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*friend).updateTime, nil
+				},
+			},
+		},
+	})
+
+	groupType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Group",
+		Description: "A group of users.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The id of the group.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Group).GetId(), nil
+				},
+			},
+			"name": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The unique name of the group.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Group).GetName(), nil
+				},
+			},
+			"description": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The description of the group.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Group).GetDescription(), nil
+				},
+			},
+			"langTag": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The language tag of the group.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Group).GetLangTag(), nil
+				},
+			},
+			"metadata": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The metadata of the group, a json object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Group).GetMetadata(), nil
+				},
+			},
+			"avatarUrl": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The url of the group's avatar.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Group).GetAvatarUrl(), nil
+				},
+			},
+			"open": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether users can join the group without being accepted.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Group).GetOpen().GetValue(), nil
+				},
+			},
+			"edgeCount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of members of the group.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Group).GetEdgeCount(), nil
+				},
+			},
+			"maxCount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The maximum number of members of the group.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Group).GetMaxCount(), nil
+				},
+			},
+			"createTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the group was created.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(*api.Group).GetCreateTime().GetSeconds(), 0), nil
+				},
+			},
+			"updateTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the group was last updated.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(*api.Group).GetUpdateTime().GetSeconds(), 0), nil
+				},
+			},
+		},
+	})
+
+	groupMemberType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "GroupMember",
+		Description: "A user within a group.",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type:        graphql.NewNonNull(userType),
+				Description: "The member.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.GroupUserList_GroupUser).GetUser(), nil
+				},
+			},
+			"role": &graphql.Field{
+				Type:        graphql.NewNonNull(groupRoleType),
+				Description: "The role of the member in the group.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.GroupUserList_GroupUser).GetState().GetValue()), nil
+				},
+			},
+		},
+	})
+
+	friendConnectionType = newConnectionType(friendType, "A page of users related to a user.")
+
+	groupMemberConnectionType = newConnectionType(groupMemberType, "A page of the members of a group.")
+
+	userGroupType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "UserGroup",
+		Description: "A group a user is in.",
+		Fields: graphql.Fields{
+			"group": &graphql.Field{
+				Type:        graphql.NewNonNull(groupType),
+				Description: "The group.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.UserGroupList_UserGroup).GetGroup(), nil
+				},
+			},
+			"role": &graphql.Field{
+				Type:        graphql.NewNonNull(groupRoleType),
+				Description: "The role of the user in the group.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(*api.UserGroupList_UserGroup).GetState().GetValue()), nil
+				},
+			},
+		},
+	})
+
+	groupField = &graphql.Field{
+		Type:        groupType,
+		Description: "Looks up a group by id.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			idParam := p.Args["id"].(string)
+			groups, err := nk.GroupsGetId(p.Context, []string{idParam})
+			if err != nil {
+				return nil, err
+			}
+			if len(groups) < 1 {
+				return nil, fmt.Errorf("no group with id `%s`", idParam)
+			}
+			return groups[0], nil
+		},
+	}
+
+	friendArgs = graphql.FieldConfigArgument{
+		"userId": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"friendId": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+	}
+
+	addFriendField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Makes two users friends, accepting any pending invite and lifting any block between them.",
+		Args:        friendArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			userID, friendID := p.Args["userId"].(string), p.Args["friendId"].(string)
+			if userID == friendID {
+				return nil, fmt.Errorf("a user cannot befriend itself")
+			}
+			return resolveFriendChange(p, "addFriend", userID, friendID, func(tx *sql.Tx, source, destination string) error {
+				return upsertFriendEdge(p.Context, tx, source, destination)
+			})
+		},
+	}
+
+	removeFriendField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Removes any relationship between two users, including invites and blocks.",
+		Args:        friendArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			userID, friendID := p.Args["userId"].(string), p.Args["friendId"].(string)
+			return resolveFriendChange(p, "removeFriend", userID, friendID, func(tx *sql.Tx, source, destination string) error {
+				return deleteFriendEdge(p.Context, tx, source, destination)
+			})
+		},
+	}
+
+	groupMemberArgs = graphql.FieldConfigArgument{
+		"groupId": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"userId": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+	}
+
+	addGroupMemberField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Adds a user to a group, or changes the role of a user already in it.",
+		Args: withArgs(groupMemberArgs, graphql.FieldConfigArgument{
+			"role": &graphql.ArgumentConfig{
+				Type:         groupRoleType,
+				DefaultValue: groupRoleMember,
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			db, err := dbFromContext(p.Context)
+			if err != nil {
+				return nil, err
+			}
+
+			groupID, userID, role := p.Args["groupId"].(string), p.Args["userId"].(string), p.Args["role"].(int)
+
+			err = inTx(p.Context, db, func(tx *sql.Tx) error {
+				if err := lockGroup(p.Context, tx, groupID); err != nil {
+					return err
+				}
+				if err := lockUser(p.Context, tx, userID); err != nil {
+					return err
+				}
+				state, err := groupEdgeState(p.Context, tx, groupID, userID)
+				if err != nil {
+					return err
+				}
+				if err := upsertGroupEdge(p.Context, tx, groupID, userID, state, role); err != nil {
+					return err
+				}
+				var before interface{}
+				if state != nil {
+					before = *state
+				}
+				return audit.RecordTx(p.Context, tx, "addGroupMember", groupMemberTarget(groupID, userID), before, role)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return true, nil
+		},
+	}
+
+	removeGroupMemberField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Removes a user from a group.",
+		Args:        groupMemberArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			groupID, userID := p.Args["groupId"].(string), p.Args["userId"].(string)
+
+			if err := nk.GroupUsersKick(p.Context, groupID, []string{userID}); err != nil {
+				return nil, err
+			}
+
+			recordApplied(p, nk, "removeGroupMember", groupMemberTarget(groupID, userID), nil, nil)
+
+			return true, nil
+		},
+	}
+)
+
+func init() {
+	// Added separately as these types refer back to users
+	groupType.AddFieldConfig("creator", &graphql.Field{
+		Type:        userType,
+		Description: "The user who created the group, null if it was created by the server.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			creatorID := p.Source.(*api.Group).GetCreatorId()
+			if creatorID == "" || creatorID == systemUserID {
+				return nil, nil
+			}
+			return loadUser(p, creatorID)
+		},
+	})
+	groupType.AddFieldConfig("members", &graphql.Field{
+		Type:        graphql.NewNonNull(groupMemberConnectionType),
+		Description: "The members of the group and their roles, by role.",
+		Args:        connectionArgs(nil),
+		Resolve:     resolveGroupMembers,
+	})
+	userType.AddFieldConfig("friends", &graphql.Field{
+		Type:        graphql.NewNonNull(friendConnectionType),
+		Description: "The users related to the user, including invites and blocks, by state.",
+		Args: connectionArgs(graphql.FieldConfigArgument{
+			"state": &graphql.ArgumentConfig{
+				Type:        friendStateType,
+				Description: "Only return relationships in this state.",
+			},
+		}),
+		Resolve: resolveFriends,
+	})
+	userType.AddFieldConfig("groups", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userGroupType))),
+		Description: "The groups the user is in and its role in each.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			return nk.UserGroupsList(p.Context, p.Source.(*api.User).GetId())
+		},
+	})
+}
+
+// resolveFriends pages through the edges of the user in p.Source in the order of Nakama's user_edge key, by state
+// and then by the position of the edge within it
+func resolveFriends(p graphql.ResolveParams) (interface{}, error) {
+
+	db, err := dbFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	first, after, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT destination_id, state, position, update_time FROM user_edge WHERE source_id = $1"
+	args := []interface{}{p.Source.(*api.User).GetId()}
+
+	if state, ok := p.Args["state"].(int); ok {
+		args = append(args, state)
+		query += fmt.Sprintf(" AND state = $%d", len(args))
+	}
+
+	if after != "" {
+		state, position, err := decodeEdgeCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, state, position)
+		query += fmt.Sprintf(" AND (state, position) > ($%d, $%d)", len(args)-1, len(args))
+	}
+
+	args = append(args, first+1)
+	query += fmt.Sprintf(" ORDER BY state, position LIMIT $%d", len(args))
+
+	rows, err := db.QueryContext(p.Context, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conn := &connection{}
+
+	ids := []string{}
+	edges := map[string]*friend{}
+	cursors := map[string]string{}
+
+	for rows.Next() {
+		if len(ids) == first {
+			conn.hasNextPage = true
+			break
+		}
+		f := &friend{}
+		var id string
+		var position int64
+		if err := rows.Scan(&id, &f.state, &position, &f.updateTime); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		edges[id] = f
+		cursors[id] = encodeEdgeCursor(f.state, position)
+		// Users missing from Nakama are left out, so the page ends at the last edge read rather than the last returned
+		conn.endCursor = cursors[id]
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	users, err := usersInOrder(p.Context, nk, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		f := edges[user.GetId()]
+		f.user = user
+		conn.edges = append(conn.edges, edge{cursors[user.GetId()], f})
+	}
+
+	return conn, nil
+}
+
+// resolveGroupMembers pages through the members of the group in p.Source in the order of Nakama's group_edge key, by
+// role and then by the position of the edge within it
+func resolveGroupMembers(p graphql.ResolveParams) (interface{}, error) {
+
+	db, err := dbFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	first, after, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT destination_id, state, position FROM group_edge WHERE source_id = $1"
+	args := []interface{}{p.Source.(*api.Group).GetId()}
+
+	if after != "" {
+		state, position, err := decodeEdgeCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, state, position)
+		query += " AND (state, position) > ($2, $3)"
+	}
+
+	args = append(args, first+1)
+	query += fmt.Sprintf(" ORDER BY state, position LIMIT $%d", len(args))
+
+	rows, err := db.QueryContext(p.Context, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conn := &connection{}
+
+	ids := []string{}
+	states := map[string]int{}
+	cursors := map[string]string{}
+
+	for rows.Next() {
+		if len(ids) == first {
+			conn.hasNextPage = true
+			break
+		}
+		var id string
+		var state int
+		var position int64
+		if err := rows.Scan(&id, &state, &position); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		states[id] = state
+		cursors[id] = encodeEdgeCursor(state, position)
+		conn.endCursor = cursors[id]
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	users, err := usersInOrder(p.Context, nk, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		member := &api.GroupUserList_GroupUser{User: user, State: &wrappers.Int32Value{Value: int32(states[user.GetId()])}}
+		conn.edges = append(conn.edges, edge{cursors[user.GetId()], member})
+	}
+
+	return conn, nil
+}
+
+func encodeEdgeCursor(state int, position int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d/%d", state, position)))
+}
+
+func decodeEdgeCursor(cursor string) (int, int64, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed cursor `%s`", cursor)
+	}
+	parts := strings.SplitN(string(bytes), "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("malformed cursor `%s`", cursor)
+	}
+	state, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed cursor `%s`", cursor)
+	}
+	position, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed cursor `%s`", cursor)
+	}
+	return state, position, nil
+}
+
+// resolveFriendChange applies change to both directions of the relationship between two users within a transaction
+func resolveFriendChange(p graphql.ResolveParams, action string, userID, friendID string, change func(tx *sql.Tx, source, destination string) error) (interface{}, error) {
+
+	db, err := dbFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	err = inTx(p.Context, db, func(tx *sql.Tx) error {
+		for _, id := range []string{userID, friendID} {
+			if err := lockUser(p.Context, tx, id); err != nil {
+				return err
+			}
+		}
+		before := map[string]interface{}{}
+		for _, pair := range [][2]string{{userID, friendID}, {friendID, userID}} {
+			var state int
+			err := tx.QueryRowContext(p.Context, "SELECT state FROM user_edge WHERE source_id = $1 AND destination_id = $2", pair[0], pair[1]).Scan(&state)
+			if err == nil {
+				before[pair[0]] = state
+			} else if err != sql.ErrNoRows {
+				return err
+			}
+			if err := change(tx, pair[0], pair[1]); err != nil {
+				return err
+			}
+		}
+		return audit.RecordTx(p.Context, tx, action, fmt.Sprintf("friend:%s/%s", userID, friendID), before, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// upsertFriendEdge marks the edge from source to destination as a friendship, counting it for source if it is new
+func upsertFriendEdge(ctx context.Context, tx *sql.Tx, source, destination string) error {
+
+	res, err := tx.ExecContext(ctx, "UPDATE user_edge SET state = $3, update_time = now() WHERE source_id = $1 AND destination_id = $2", source, destination, friendStateFriend)
+	if err != nil {
+		return err
+	}
+	if updated, err := res.RowsAffected(); err != nil || updated > 0 {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO user_edge (source_id, destination_id, state, position, update_time) VALUES ($1, $2, $3, $4, now())",
+		source, destination, friendStateFriend, time.Now().UTC().UnixNano()); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE users SET edge_count = edge_count + 1, update_time = now() WHERE id = $1", source)
+	return err
+}
+
+// deleteFriendEdge removes the edge from source to destination, uncounting it for source if it existed
+func deleteFriendEdge(ctx context.Context, tx *sql.Tx, source, destination string) error {
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM user_edge WHERE source_id = $1 AND destination_id = $2", source, destination)
+	if err != nil {
+		return err
+	}
+	if deleted, err := res.RowsAffected(); err != nil || deleted == 0 {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE users SET edge_count = edge_count - 1, update_time = now() WHERE id = $1", source)
+	return err
+}
+
+func groupEdgeState(ctx context.Context, tx *sql.Tx, groupID, userID string) (*int, error) {
+	var state int
+	err := tx.QueryRowContext(ctx, "SELECT state FROM group_edge WHERE source_id = $1 AND destination_id = $2", groupID, userID).Scan(&state)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// upsertGroupEdge sets the role of a user in a group, counting it as a member of the group if it was not one before
+func upsertGroupEdge(ctx context.Context, tx *sql.Tx, groupID, userID string, state *int, role int) error {
+
+	if state == nil {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO group_edge (position, state, source_id, destination_id) VALUES ($1, $2, $3, $4), ($1, $2, $4, $3)",
+			time.Now().UTC().UnixNano(), role, groupID, userID); err != nil {
+			return err
+		}
+	} else if _, err := tx.ExecContext(ctx, "UPDATE group_edge SET state = $3, update_time = now() WHERE (source_id = $1 AND destination_id = $2) OR (source_id = $2 AND destination_id = $1)",
+		groupID, userID, role); err != nil {
+		return err
+	}
+
+	wasMember := state != nil && *state != groupRoleJoinRequest
+	isMember := role != groupRoleJoinRequest
+
+	if wasMember == isMember {
+		return nil
+	}
+
+	if !isMember {
+		_, err := tx.ExecContext(ctx, "UPDATE groups SET edge_count = edge_count - 1, update_time = now() WHERE id = $1", groupID)
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE groups SET edge_count = edge_count + 1, update_time = now() WHERE id = $1 AND edge_count + 1 <= max_count AND disable_time = '1970-01-01 00:00:00'", groupID)
+	if err != nil {
+		return err
+	}
+	if updated, err := res.RowsAffected(); err != nil {
+		return err
+	} else if updated == 0 {
+		return fmt.Errorf("group `%s` is full", groupID)
+	}
+
+	return nil
+}
+
+// lockUser locks the row of a user for the rest of tx, so its edges are not changed while it is deleted
+func lockUser(ctx context.Context, tx *sql.Tx, userID string) error {
+	var id string
+	err := tx.QueryRowContext(ctx, "SELECT id FROM users WHERE id = $1 FOR UPDATE", userID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("no user with id `%s`", userID)
+	}
+	return err
+}
+
+// lockGroup locks the row of a group which was not disabled for the rest of tx, so its edges and count change together
+func lockGroup(ctx context.Context, tx *sql.Tx, groupID string) error {
+	var id string
+	err := tx.QueryRowContext(ctx, "SELECT id FROM groups WHERE id = $1 AND disable_time = '1970-01-01 00:00:00' FOR UPDATE", groupID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("no group with id `%s`", groupID)
+	}
+	return err
+}
+
+func groupMemberTarget(groupID, userID string) string {
+	return fmt.Sprintf("group:%s/%s", groupID, userID)
+}
+
+// inTx runs fn within a transaction, committing it if fn succeeds
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
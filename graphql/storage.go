@@ -0,0 +1,230 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+// maxScannedPages bounds the pages of a collection scanned for objects matching a key prefix within a single request
+const maxScannedPages = 10
+
+// storageCursor points right after an object, as the Nakama cursor of the page holding it and its offset within that page
+type storageCursor struct {
+	PageCursor string `json:"c"`
+	Offset     int    `json:"o"`
+}
+
+var (
+	storageType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "StorageObject",
+		Description: "A storage object persisted on Nakama.",
+		Fields: graphql.Fields{
+			"collection": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The collection which stores the object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.StorageObject).GetCollection(), nil
+				},
+			},
+			"key": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The key defining the stored object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.StorageObject).GetKey(), nil
+				},
+			},
+			"userId": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The id of the user owning the object, empty for global objects.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.StorageObject).GetUserId(), nil
+				},
+			},
+			"value": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The value stored in the object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.StorageObject).GetValue(), nil
+				},
+			},
+			"json": &graphql.Field{
+				Type:        jsonType,
+				Description: "The value stored in the object, as json that can be explored in the response.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return json.RawMessage(p.Source.(*api.StorageObject).GetValue()), nil
+				},
+			},
+			"valueAt": &graphql.Field{
+				Type:        jsonType,
+				Description: "The part of the value at a dotted path, such as `inventory.0.name`, null if there is nothing there.",
+				Args: graphql.FieldConfigArgument{
+					"path": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var value interface{}
+					if err := json.Unmarshal([]byte(p.Source.(*api.StorageObject).GetValue()), &value); err != nil {
+						return nil, err
+					}
+					value, _ = valueAt(value, p.Args["path"].(string))
+					return value, nil
+				},
+			},
+			"version": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The version hash of the object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.StorageObject).GetVersion(), nil
+				},
+			},
+			"permissionRead": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The read access permissions for the object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.StorageObject).GetPermissionRead(), nil
+				},
+			},
+			"permissionWrite": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The write access permissions for the object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.StorageObject).GetPermissionWrite(), nil
+				},
+			},
+			"createTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the object was created.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(*api.StorageObject).GetCreateTime().GetSeconds(), 0), nil
+				},
+			},
+			"updateTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the object was last updated.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(*api.StorageObject).GetUpdateTime().GetSeconds(), 0), nil
+				},
+			},
+		},
+	})
+
+	storageConnectionType = newConnectionType(storageType, "A page of storage objects.")
+
+	storageConnectionArgs = connectionArgs(graphql.FieldConfigArgument{
+		"collection": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"keyPrefix": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "Only return objects whose key starts with this prefix. As objects are filtered while the collection is scanned, a page may hold fewer objects than requested, or none, while `hasNextPage` is true, `endCursor` continuing the scan.",
+		},
+	})
+)
+
+func init() {
+	// Added separately as the user type refers back to storage objects
+	storageType.AddFieldConfig("owner", &graphql.Field{
+		Type:        userType,
+		Description: "The user owning the object, null for global objects.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			userID := p.Source.(*api.StorageObject).GetUserId()
+			if userID == "" {
+				return nil, nil
+			}
+			return loadUser(p, userID)
+		},
+	})
+}
+
+// resolveStorageConnection lists a page of the storage collection in p.Args owned by userID
+func resolveStorageConnection(p graphql.ResolveParams, userID string) (interface{}, error) {
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	collection := p.Args["collection"].(string)
+	prefix, _ := p.Args["keyPrefix"].(string)
+
+	first, after, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor storageCursor
+	if after != "" {
+		if cursor, err = decodeStorageCursor(after); err != nil {
+			return nil, err
+		}
+	}
+
+	// One more object than requested is looked for, to know whether there is a next page
+	batch := first + 1
+	if batch > maxPageSize {
+		batch = maxPageSize
+	}
+
+	conn := &connection{}
+
+	for scanned := 1; ; scanned++ {
+		objs, next, err := nk.StorageList(p.Context, userID, collection, batch, cursor.PageCursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := cursor.Offset; i < len(objs) && !conn.hasNextPage; i++ {
+			if !strings.HasPrefix(objs[i].GetKey(), prefix) {
+				continue
+			}
+			if len(conn.edges) == first {
+				conn.hasNextPage = true
+				break
+			}
+			edgeCursor, err := encodeStorageCursor(storageCursor{cursor.PageCursor, i + 1})
+			if err != nil {
+				return nil, err
+			}
+			conn.edges = append(conn.edges, edge{edgeCursor, objs[i]})
+		}
+
+		if conn.hasNextPage || len(objs) < batch || next == "" || next == cursor.PageCursor {
+			return conn, nil
+		}
+
+		cursor = storageCursor{next, 0}
+
+		// A prefix matching few objects would otherwise scan the whole collection
+		if scanned == maxScannedPages {
+			conn.hasNextPage = true
+			if conn.endCursor, err = encodeStorageCursor(cursor); err != nil {
+				return nil, err
+			}
+			return conn, nil
+		}
+	}
+}
+
+func encodeStorageCursor(cursor storageCursor) (string, error) {
+	bytes, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func decodeStorageCursor(encoded string) (storageCursor, error) {
+	var cursor storageCursor
+	bytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err == nil {
+		err = json.Unmarshal(bytes, &cursor)
+	}
+	if err != nil {
+		return cursor, fmt.Errorf("malformed cursor `%s`", encoded)
+	}
+	return cursor, nil
+}
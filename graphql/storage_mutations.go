@@ -0,0 +1,294 @@
+package graphql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/audit"
+)
+
+var (
+	// reservedCollections are the storage collections storage mutations refuse to change, as the audit log and the
+	// credentials of the ui's admin users are kept in them
+	reservedCollections      = map[string]bool{audit.CollectionID: true, "admin_users": true}
+	reservedCollectionsMutex sync.RWMutex
+
+	storageObjectArgs = graphql.FieldConfigArgument{
+		"collection": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"key": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"userId": &graphql.ArgumentConfig{
+			Type:         graphql.String,
+			DefaultValue: "",
+			Description:  "The id of the user owning the object, empty for global objects.",
+		},
+		"version": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "The version the object is expected to be at, `*` to only allow creating it.",
+		},
+	}
+
+	writeStorageField = &graphql.Field{
+		Type:        graphql.NewNonNull(storageType),
+		Description: "Writes a storage object, replacing its value.",
+		Args: withArgs(storageObjectArgs, graphql.FieldConfigArgument{
+			"value": &graphql.ArgumentConfig{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The new value of the object, a json object.",
+			},
+			"permissionRead": &graphql.ArgumentConfig{
+				Type:        graphql.Int,
+				Description: "The read permission of the object, kept as is for existing objects when omitted.",
+			},
+			"permissionWrite": &graphql.ArgumentConfig{
+				Type:        graphql.Int,
+				Description: "The write permission of the object, kept as is for existing objects when omitted.",
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var value map[string]interface{}
+			if err := json.Unmarshal([]byte(p.Args["value"].(string)), &value); err != nil {
+				return nil, fmt.Errorf("value must be a json object: %s", err)
+			}
+			return updateStorage(p, "writeStorage", func(existing *api.StorageObject) (interface{}, error) {
+				return value, nil
+			})
+		},
+	}
+
+	patchStorageField = &graphql.Field{
+		Type:        graphql.NewNonNull(storageType),
+		Description: "Applies a json merge patch (RFC 7386) to the value of a storage object.",
+		Args: withArgs(storageObjectArgs, graphql.FieldConfigArgument{
+			"patch": &graphql.ArgumentConfig{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The merge patch, a json object where null values remove fields.",
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var patch map[string]interface{}
+			if err := json.Unmarshal([]byte(p.Args["patch"].(string)), &patch); err != nil {
+				return nil, fmt.Errorf("patch must be a json object: %s", err)
+			}
+			return updateStorage(p, "patchStorage", func(existing *api.StorageObject) (interface{}, error) {
+				var value interface{}
+				if existing != nil {
+					if err := json.Unmarshal([]byte(existing.GetValue()), &value); err != nil {
+						return nil, err
+					}
+				}
+				return mergePatch(value, patch), nil
+			})
+		},
+	}
+
+	deleteStorageField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: "Deletes a storage object, returning whether it existed.",
+		Args:        storageObjectArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			collection, key, userID := p.Args["collection"].(string), p.Args["key"].(string), p.Args["userId"].(string)
+			target := storageTarget(collection, key, userID)
+
+			if err := checkCollection(collection); err != nil {
+				return nil, err
+			}
+
+			db, err := dbFromContext(p.Context)
+			if err != nil {
+				return nil, err
+			}
+
+			existing, err := readStorageObject(p.Context, nk, collection, key, userID)
+			if err != nil {
+				return nil, err
+			}
+			if existing == nil {
+				return false, nil
+			}
+
+			// Deleting what was read unless told otherwise, so that a concurrent change is not deleted unseen
+			version, ok := p.Args["version"].(string)
+			if !ok {
+				version = existing.GetVersion()
+			}
+			owner := userID
+			if owner == "" {
+				owner = systemUserID
+			}
+
+			// Nakama has no api deleting objects along with writing others, the entry is recorded in the same transaction
+			err = inTx(p.Context, db, func(tx *sql.Tx) error {
+				res, err := tx.ExecContext(p.Context, "DELETE FROM storage WHERE collection = $1 AND key = $2 AND user_id = $3 AND version = $4", collection, key, owner, version)
+				if err != nil {
+					return err
+				}
+				if deleted, err := res.RowsAffected(); err != nil || deleted == 0 {
+					if err == nil {
+						err = fmt.Errorf("storage object `%s` was not deleted as it is no longer at version `%s`", target, version)
+					}
+					return err
+				}
+				return audit.RecordTx(p.Context, tx, "deleteStorage", target, json.RawMessage(existing.GetValue()), nil)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return true, nil
+		},
+	}
+)
+
+// updateStorage writes the value produced by update from the existing object, guarding against concurrent changes
+// with the version argument or, when omitted, the version of the object update was given
+func updateStorage(p graphql.ResolveParams, action string, update func(existing *api.StorageObject) (interface{}, error)) (interface{}, error) {
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	collection, key, userID := p.Args["collection"].(string), p.Args["key"].(string), p.Args["userId"].(string)
+
+	if err := checkCollection(collection); err != nil {
+		return nil, err
+	}
+
+	existing, err := readStorageObject(p.Context, nk, collection, key, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := update(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	write := &runtime.StorageWrite{
+		Collection:      collection,
+		Key:             key,
+		UserID:          userID,
+		Value:           string(bytes),
+		Version:         "*",
+		PermissionRead:  1,
+		PermissionWrite: 1,
+	}
+
+	var before interface{}
+
+	if existing != nil {
+		before = json.RawMessage(existing.GetValue())
+		write.Version = existing.GetVersion()
+		write.PermissionRead = int(existing.GetPermissionRead())
+		write.PermissionWrite = int(existing.GetPermissionWrite())
+	}
+	if version, ok := p.Args["version"].(string); ok {
+		write.Version = version
+	}
+	if permission, ok := p.Args["permissionRead"].(int); ok {
+		write.PermissionRead = permission
+	}
+	if permission, ok := p.Args["permissionWrite"].(int); ok {
+		write.PermissionWrite = permission
+	}
+
+	entry, err := audit.NewWrite(p.Context, action, storageTarget(collection, key, userID), before, json.RawMessage(write.Value))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := nk.StorageWrite(p.Context, []*runtime.StorageWrite{write, entry}); err != nil {
+		return nil, err
+	}
+
+	obj, err := readStorageObject(p.Context, nk, collection, key, userID)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("storage object `%s` was not found after writing it", storageTarget(collection, key, userID))
+	}
+
+	return obj, nil
+}
+
+// ReserveCollection makes storage mutations refuse to change the objects of collection, for collections whose objects
+// are trusted by other modules, such as the credentials of admin users
+func ReserveCollection(collection string) {
+	reservedCollectionsMutex.Lock()
+	defer reservedCollectionsMutex.Unlock()
+	reservedCollections[collection] = true
+}
+
+func checkCollection(collection string) error {
+	reservedCollectionsMutex.RLock()
+	defer reservedCollectionsMutex.RUnlock()
+	if reservedCollections[collection] {
+		return fmt.Errorf("storage collection `%s` is reserved and cannot be changed through storage mutations", collection)
+	}
+	return nil
+}
+
+func readStorageObject(ctx context.Context, nk runtime.NakamaModule, collection, key, userID string) (*api.StorageObject, error) {
+	objs, err := nk.StorageRead(ctx, []*runtime.StorageRead{
+		&runtime.StorageRead{
+			Collection: collection,
+			Key:        key,
+			UserID:     userID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) < 1 {
+		return nil, nil
+	}
+	return objs[0], nil
+}
+
+func storageTarget(collection, key, userID string) string {
+	return fmt.Sprintf("storage:%s/%s/%s", collection, key, userID)
+}
+
+// mergePatch applies a json merge patch as defined in RFC 7386
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = map[string]interface{}{}
+	}
+	for k, v := range patchMap {
+		if v == nil {
+			delete(targetMap, k)
+		} else {
+			targetMap[k] = mergePatch(targetMap[k], v)
+		}
+	}
+	return targetMap
+}
+
+func withArgs(args ...graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	merged := graphql.FieldConfigArgument{}
+	for _, a := range args {
+		for name, arg := range a {
+			merged[name] = arg
+		}
+	}
+	return merged
+}
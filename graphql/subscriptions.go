@@ -0,0 +1,284 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+const (
+	minPollInterval = 1
+	maxPollInterval = 3600
+
+	// metricsMatchLimit bounds the number of matches of each kind listed to compute metrics
+	metricsMatchLimit = 10000
+)
+
+// Subscription is a root subscription field, resolved once for every event of the stream it starts for a subscriber
+type Subscription struct {
+	// Field resolves each event, given as p.Source, resolving to the event itself if it has no resolver.
+	// An event which is an error is reported as the field's error instead.
+	Field *graphql.Field
+	// Subscribe starts the stream of events of a subscriber given the field's arguments,
+	// the stream must be closed once p.Context is done
+	Subscribe func(p graphql.ResolveParams) (<-chan interface{}, error)
+}
+
+// Subscriptions are the root subscription fields of an extension
+type Subscriptions map[string]*Subscription
+
+// subscriptionStart is the root value of the execution starting a subscription, holding the stream it started
+type subscriptionStart struct {
+	events  <-chan interface{}
+	started int
+	err     error
+}
+
+// subscriptionEvent is the root value of the executions resolving the events of a subscription
+type subscriptionEvent struct {
+	value interface{}
+}
+
+// Metrics are server figures sampled at a point in time
+type Metrics struct {
+	Time                 time.Time
+	AuthoritativeMatches int
+	RelayedMatches       int
+	Players              int
+}
+
+var (
+	metricsType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Metrics",
+		Description: "Server figures sampled at a point in time.",
+		Fields: graphql.Fields{
+			"time": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Metrics).Time, nil
+				},
+			},
+			"authoritativeMatches": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: fmt.Sprintf("The number of running authoritative matches, counting up to %d.", metricsMatchLimit),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Metrics).AuthoritativeMatches, nil
+				},
+			},
+			"relayedMatches": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: fmt.Sprintf("The number of running relayed matches, counting up to %d.", metricsMatchLimit),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Metrics).RelayedMatches, nil
+				},
+			},
+			"players": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of users in the counted matches.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Metrics).Players, nil
+				},
+			},
+		},
+	})
+
+	pollArgs = graphql.FieldConfigArgument{
+		"intervalSeconds": &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: 5,
+			Description:  fmt.Sprintf("The time between events, from %d to %d seconds.", minPollInterval, maxPollInterval),
+		},
+	}
+
+	pollSubscription = &Subscription{
+		Field: &graphql.Field{
+			Type:        graphql.NewNonNull(rootQuery),
+			Description: "Executes the selected queries right away and then periodically, such as to watch a user's ledger.",
+			Args:        pollArgs,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return struct{}{}, nil
+			},
+		},
+		Subscribe: func(p graphql.ResolveParams) (<-chan interface{}, error) {
+			return poll(p, func(now time.Time) interface{} { return now })
+		},
+	}
+
+	metricsSubscription = &Subscription{
+		Field: &graphql.Field{
+			Type:        graphql.NewNonNull(metricsType),
+			Description: "Samples server metrics right away and then periodically.",
+			Args:        pollArgs,
+		},
+		Subscribe: func(p graphql.ResolveParams) (<-chan interface{}, error) {
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			return poll(p, func(now time.Time) interface{} {
+				metrics, err := sampleMetrics(p.Context, nk, now)
+				if err != nil {
+					return err
+				}
+				return metrics
+			})
+		},
+	}
+
+	builtinSubscriptions = Subscriptions{
+		"poll":    pollSubscription,
+		"metrics": metricsSubscription,
+	}
+)
+
+// fields returns the root fields executing the subscriptions
+func (subs Subscriptions) fields() (graphql.Fields, error) {
+	fields := graphql.Fields{}
+	for name, sub := range subs {
+		if sub.Field == nil || sub.Subscribe == nil {
+			return nil, fmt.Errorf("subscription `%s` needs both a field and a subscribe function", name)
+		}
+		field := *sub.Field
+		field.Resolve = subscriptionResolver(sub)
+		fields[name] = &field
+	}
+	return fields, nil
+}
+
+func subscriptionResolver(sub *Subscription) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		switch root := p.Source.(type) {
+		case *subscriptionStart:
+			// Only one field may start the stream, whether selected directly or through fragments
+			if root.started++; root.started > 1 {
+				root.events, root.err = nil, errors.New("subscriptions must select exactly one root field")
+				return nil, root.err
+			}
+			root.events, root.err = sub.Subscribe(p)
+			return nil, root.err
+		case subscriptionEvent:
+			if err, ok := root.value.(error); ok {
+				return nil, err
+			}
+			p.Source = root.value
+			if sub.Field.Resolve == nil {
+				return root.value, nil
+			}
+			return sub.Field.Resolve(p)
+		}
+		return nil, errors.New("subscriptions are only served over the websocket of the ui server")
+	}
+}
+
+// Subscribe executes a GraphQL subscription, responding to each event of the stream it starts until ctx is done or
+// the stream ends, after which the returned channel is closed. Queries, mutations and requests that fail before
+// the stream is started get a single response.
+func Subscribe(ctx context.Context, nk runtime.NakamaModule, request *GraphQLRequest) <-chan *GraphQLResponse {
+
+	responses := make(chan *GraphQLResponse, 1)
+
+	doc, failed := parse(request)
+	if failed != nil {
+		responses <- failed
+		close(responses)
+		return responses
+	}
+
+	if op := operation(doc, request.OperationName); op == nil || op.Operation != ast.OperationTypeSubscription {
+		responses <- execute(ctx, nk, doc, request, nil)
+		close(responses)
+		return responses
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	start := &subscriptionStart{}
+	started := execute(ctx, nk, doc, request, start)
+
+	if start.events == nil {
+		cancel()
+		if start.err != nil {
+			started = newResponse(nil, gqlerrors.FormatErrors(start.err))
+		}
+		responses <- started
+		close(responses)
+		return responses
+	}
+
+	go func() {
+		defer close(responses)
+		defer cancel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-start.events:
+				if !ok {
+					return
+				}
+				select {
+				case responses <- execute(ctx, nk, doc, request, subscriptionEvent{event}):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return responses
+}
+
+// poll sends an event right away and then every `intervalSeconds` until p.Context is done
+func poll(p graphql.ResolveParams, event func(now time.Time) interface{}) (<-chan interface{}, error) {
+
+	interval := p.Args["intervalSeconds"].(int)
+	if interval < minPollInterval || interval > maxPollInterval {
+		return nil, fmt.Errorf("`intervalSeconds` must be between %d and %d", minPollInterval, maxPollInterval)
+	}
+
+	events := make(chan interface{})
+
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		now := time.Now()
+		for {
+			select {
+			case events <- event(now):
+			case <-p.Context.Done():
+				return
+			}
+			select {
+			case now = <-ticker.C:
+			case <-p.Context.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func sampleMetrics(ctx context.Context, nk runtime.NakamaModule, now time.Time) (*Metrics, error) {
+	metrics := &Metrics{Time: now}
+	for _, authoritative := range []bool{true, false} {
+		matches, err := nk.MatchList(ctx, metricsMatchLimit, authoritative, "", 0, math.MaxInt32, "")
+		if err != nil {
+			return nil, err
+		}
+		if authoritative {
+			metrics.AuthoritativeMatches = len(matches)
+		} else {
+			metrics.RelayedMatches = len(matches)
+		}
+		for _, match := range matches {
+			metrics.Players += int(match.GetSize())
+		}
+	}
+	return metrics, nil
+}
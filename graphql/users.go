@@ -0,0 +1,460 @@
+package graphql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+const (
+	// systemUserID is the id of Nakama's system user, which is left out of searches
+	systemUserID = "00000000-0000-0000-0000-000000000000"
+)
+
+var (
+	accountType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Account",
+		Description: "A registered Nakama user account.",
+		Fields: graphql.Fields{
+			"customId": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The custom id of the account.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Account).GetCustomId(), nil
+				},
+			},
+			"email": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The email of the account.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Account).GetEmail(), nil
+				},
+			},
+			"devices": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Description: "The ids of the devices linked to the account.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					devices := []string{}
+					for _, device := range p.Source.(*api.Account).GetDevices() {
+						devices = append(devices, device.GetId())
+					}
+					return devices, nil
+				},
+			},
+			"verifyTime": &graphql.Field{
+				Type:        graphql.DateTime,
+				Description: "The time the account's email was verified, null if it was not.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalTime(p.Source.(*api.Account).GetVerifyTime()), nil
+				},
+			},
+			"wallet": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(walletBalanceType))),
+				Description: "The balances in the wallet of the account, with nested currencies named by their dotted path.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var wallet map[string]interface{}
+					if err := json.Unmarshal([]byte(p.Source.(*api.Account).GetWallet()), &wallet); err != nil {
+						return nil, err
+					}
+					return walletBalances(wallet), nil
+				},
+			},
+			"walletJson": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The wallet of the account as stored, a json object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.Account).GetWallet(), nil
+				},
+			},
+		},
+	})
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "A registered Nakama user.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The id of the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetId(), nil
+				},
+			},
+			"username": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The username of the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetUsername(), nil
+				},
+			},
+			"displayName": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The display name of the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetDisplayName(), nil
+				},
+			},
+			"avatarUrl": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The url of the user's avatar.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetAvatarUrl(), nil
+				},
+			},
+			"langTag": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The language tag of the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetLangTag(), nil
+				},
+			},
+			"location": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The location of the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetLocation(), nil
+				},
+			},
+			"timezone": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The timezone of the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetTimezone(), nil
+				},
+			},
+			"metadata": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The metadata of the user, a json object.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetMetadata(), nil
+				},
+			},
+			"facebookId": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The Facebook id linked to the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetFacebookId(), nil
+				},
+			},
+			"googleId": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The Google id linked to the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetGoogleId(), nil
+				},
+			},
+			"gamecenterId": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The Apple Game Center id linked to the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetGamecenterId(), nil
+				},
+			},
+			"steamId": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The Steam id linked to the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetSteamId(), nil
+				},
+			},
+			"online": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether the user is currently connected.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetOnline(), nil
+				},
+			},
+			"edgeCount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of friends and groups of the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*api.User).GetEdgeCount(), nil
+				},
+			},
+			"createTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the user was created.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(*api.User).GetCreateTime().GetSeconds(), 0), nil
+				},
+			},
+			"updateTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time the user was last updated.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(*api.User).GetUpdateTime().GetSeconds(), 0), nil
+				},
+			},
+			"account": &graphql.Field{
+				Type:        graphql.NewNonNull(accountType),
+				Description: "The account of the user.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadAccount(p, p.Source.(*api.User).GetId())
+				},
+			},
+			"storage": &graphql.Field{
+				Type:        graphql.NewNonNull(storageConnectionType),
+				Args:        storageConnectionArgs,
+				Description: "The storage objects the user owns in a collection.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveStorageConnection(p, p.Source.(*api.User).GetId())
+				},
+			},
+			"ledger": &graphql.Field{
+				Type:        graphql.NewNonNull(ledgerConnectionType),
+				Args:        ledgerConnectionArgs,
+				Description: "The user's wallet transaction ledger, most recent first.",
+				Resolve:     resolveLedgerConnection,
+			},
+			"notifications": &graphql.Field{
+				Type:        graphql.NewNonNull(notificationConnectionType),
+				Args:        connectionArgs(nil),
+				Description: "The notifications kept in the user's history, most recent first.",
+				Resolve:     resolveNotificationConnection,
+			},
+		},
+	})
+
+	userConnectionType = newConnectionType(userType, "A page of users.")
+
+	userByIdField = &graphql.Field{
+		Type:        userType,
+		Description: "Looks up a user by id.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			idParam := p.Args["id"].(string)
+			return resolveUserById(p, idParam, fmt.Sprintf("no user with id `%s`", idParam))
+		},
+	}
+
+	usersByIdField = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+		Description: "Looks up a batch of users by their ids, in the order given, leaving out ids with no user.",
+		Args: graphql.FieldConfigArgument{
+			"ids": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			ids := []string{}
+			for _, id := range p.Args["ids"].([]interface{}) {
+				ids = append(ids, id.(string))
+			}
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			return usersInOrder(p.Context, nk, ids)
+		},
+	}
+
+	userByCustomIdField = &graphql.Field{
+		Type:        userType,
+		Description: "Looks up a user by the custom id it authenticates with.",
+		Args: graphql.FieldConfigArgument{
+			"customId": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			customIdParam := p.Args["customId"].(string)
+			return resolveUserByQuery(p, "SELECT id FROM users WHERE custom_id = $1", customIdParam,
+				fmt.Sprintf("no user with custom id `%s`", customIdParam))
+		},
+	}
+
+	userByDeviceIdField = &graphql.Field{
+		Type:        userType,
+		Description: "Looks up a user by the id of a device linked to it.",
+		Args: graphql.FieldConfigArgument{
+			"deviceId": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			deviceIdParam := p.Args["deviceId"].(string)
+			return resolveUserByQuery(p, "SELECT user_id FROM user_device WHERE id = $1", deviceIdParam,
+				fmt.Sprintf("no user with device id `%s`", deviceIdParam))
+		},
+	}
+
+	userByEmailField = &graphql.Field{
+		Type:        userType,
+		Description: "Looks up a user by email.",
+		Args: graphql.FieldConfigArgument{
+			"email": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			emailParam := p.Args["email"].(string)
+			// Nakama stores emails in lower case
+			return resolveUserByQuery(p, "SELECT id FROM users WHERE email = $1", strings.ToLower(emailParam),
+				fmt.Sprintf("no user with email `%s`", emailParam))
+		},
+	}
+
+	searchUsersField = &graphql.Field{
+		Type:        graphql.NewNonNull(userConnectionType),
+		Description: "Searches for users whose username starts with a prefix, ordered by username.",
+		Args: connectionArgs(graphql.FieldConfigArgument{
+			"usernamePrefix": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		}),
+		Resolve: resolveSearchUsers,
+	}
+)
+
+func optionalTime(ts *timestamp.Timestamp) interface{} {
+	if ts == nil || ts.GetSeconds() == 0 {
+		return nil
+	}
+	return time.Unix(ts.GetSeconds(), 0)
+}
+
+func dbFromContext(ctx context.Context) (*sql.DB, error) {
+	db, _ := ctx.Value(GRAPHQL_CTX_DB).(*sql.DB)
+	if db == nil {
+		return nil, fmt.Errorf("no database is available to the graphql operation")
+	}
+	return db, nil
+}
+
+func resolveUserById(p graphql.ResolveParams, userID string, notFound string) (interface{}, error) {
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	users, err := nk.UsersGetId(p.Context, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) < 1 {
+		return nil, errors.New(notFound)
+	}
+	return users[0], nil
+}
+
+// resolveUserByQuery looks up the user whose id is selected by a single row query over Nakama's tables
+func resolveUserByQuery(p graphql.ResolveParams, query string, arg string, notFound string) (interface{}, error) {
+
+	db, err := dbFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	var userID string
+
+	err = db.QueryRowContext(p.Context, query, arg).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(notFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveUserById(p, userID, notFound)
+}
+
+// usersInOrder gets the users of ids ordered as ids are
+func usersInOrder(ctx context.Context, nk runtime.NakamaModule, ids []string) ([]*api.User, error) {
+
+	if len(ids) == 0 {
+		return []*api.User{}, nil
+	}
+
+	users, err := nk.UsersGetId(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string]*api.User{}
+	for _, user := range users {
+		byID[user.GetId()] = user
+	}
+
+	ordered := []*api.User{}
+	for _, id := range ids {
+		if user, has := byID[id]; has {
+			ordered = append(ordered, user)
+		}
+	}
+
+	return ordered, nil
+}
+
+func resolveSearchUsers(p graphql.ResolveParams) (interface{}, error) {
+
+	db, err := dbFromContext(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	first, after, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	afterUsername := ""
+	if after != "" {
+		bytes, err := base64.RawURLEncoding.DecodeString(after)
+		if err != nil {
+			return nil, fmt.Errorf("malformed cursor `%s`", after)
+		}
+		afterUsername = string(bytes)
+	}
+
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	pattern := escaper.Replace(p.Args["usernamePrefix"].(string)) + "%"
+
+	rows, err := db.QueryContext(p.Context,
+		"SELECT id, username FROM users WHERE username LIKE $1 AND username > $2 AND id <> $3 ORDER BY username LIMIT $4",
+		pattern, afterUsername, systemUserID, first+1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	cursors := map[string]string{}
+
+	for rows.Next() {
+		var id, username string
+		if err := rows.Scan(&id, &username); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		cursors[id] = base64.RawURLEncoding.EncodeToString([]byte(username))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	conn := &connection{}
+
+	if len(ids) > first {
+		ids = ids[:first]
+		conn.hasNextPage = true
+	}
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+	users, err := usersInOrder(p.Context, nk, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		conn.edges = append(conn.edges, edge{cursors[user.GetId()], user})
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,291 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/audit"
+)
+
+type walletBalance struct {
+	currency string
+	amount   float64
+}
+
+type ledgerItemMetadataItem struct {
+	key   string
+	value string
+}
+
+var (
+	walletBalanceType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "WalletBalance",
+		Description: "An amount of a currency in a wallet, or changed by a ledger item.",
+		Fields: graphql.Fields{
+			"currency": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The currency, nested currencies are named by their dotted path.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(walletBalance).currency, nil
+				},
+			},
+			"amount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Float),
+				Description: "The amount of the currency.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(walletBalance).amount, nil
+				},
+			},
+		},
+	})
+
+	ledgerItemMetadataItemType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "LedgerItemMetadataItem",
+		Description: "A metadata entry of a ledger item.",
+		Fields: graphql.Fields{
+			"key": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ledgerItemMetadataItem).key, nil
+				},
+			},
+			"value": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The value, json encoded unless it is a string.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ledgerItemMetadataItem).value, nil
+				},
+			},
+		},
+	})
+
+	ledgerItemType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "LedgerItem",
+		Description: "A change made to the wallet of a user.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The ledger item Id.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(runtime.WalletLedgerItem).GetID(), nil
+				},
+			},
+			"createTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The ledger item creation time.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(runtime.WalletLedgerItem).GetCreateTime(), 0), nil
+				},
+			},
+			"updateTime": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The ledger update time.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Unix(p.Source.(runtime.WalletLedgerItem).GetUpdateTime(), 0), nil
+				},
+			},
+			"changeset": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(walletBalanceType))),
+				Description: "The ledger item changeset.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return walletBalances(p.Source.(runtime.WalletLedgerItem).GetChangeset()), nil
+				},
+			},
+			"metadata": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(ledgerItemMetadataItemType))),
+				Description: "The ledger item metadata.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					metadata := []ledgerItemMetadataItem{}
+					for k, v := range p.Source.(runtime.WalletLedgerItem).GetMetadata() {
+						value, ok := v.(string)
+						if !ok {
+							bytes, err := json.Marshal(v)
+							if err != nil {
+								return nil, err
+							}
+							value = string(bytes)
+						}
+						metadata = append(metadata, ledgerItemMetadataItem{k, value})
+					}
+					sort.Slice(metadata, func(i, j int) bool { return metadata[i].key < metadata[j].key })
+					return metadata, nil
+				},
+			},
+		},
+	})
+
+	ledgerConnectionType = newConnectionType(ledgerItemType, "A page of ledger items.")
+
+	ledgerConnectionArgs = connectionArgs(graphql.FieldConfigArgument{
+		"since": &graphql.ArgumentConfig{
+			Type:        graphql.DateTime,
+			Description: "Only return items created at or after this time.",
+		},
+		"until": &graphql.ArgumentConfig{
+			Type:        graphql.DateTime,
+			Description: "Only return items created before this time.",
+		},
+	})
+
+	walletUpdateField = &graphql.Field{
+		Type:        graphql.NewNonNull(accountType),
+		Description: "Adds amounts to the wallet of a user, recording the change in its ledger along with the reason for it.",
+		Args: graphql.FieldConfigArgument{
+			"userId": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"changeset": &graphql.ArgumentConfig{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The amounts to add to each currency, a json object, negative amounts are deducted.",
+			},
+			"reason": &graphql.ArgumentConfig{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Why the wallet is being updated, stored in the ledger item's metadata.",
+			},
+			"metadata": &graphql.ArgumentConfig{
+				Type:         graphql.String,
+				DefaultValue: "{}",
+				Description:  "Additional metadata of the ledger item, a json object.",
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+			userID := p.Args["userId"].(string)
+
+			reason := strings.TrimSpace(p.Args["reason"].(string))
+			if reason == "" {
+				return nil, fmt.Errorf("`reason` must not be empty")
+			}
+
+			changeset, err := jsonObjectArg(p, "changeset")
+			if err != nil {
+				return nil, err
+			}
+
+			metadata, err := jsonObjectArg(p, "metadata")
+			if err != nil {
+				return nil, err
+			}
+			metadata["reason"] = reason
+			metadata["actor"] = audit.Actor(p.Context)
+
+			before, err := nk.AccountGetId(p.Context, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := nk.WalletUpdate(p.Context, userID, changeset, metadata, true); err != nil {
+				return nil, err
+			}
+
+			// The wallet is updated from here on, so no error may make it look otherwise and have it updated twice
+			after, err := nk.AccountGetId(p.Context, userID)
+			if err != nil {
+				recordApplied(p, nk, "walletUpdate", "wallet:"+userID, json.RawMessage(before.GetWallet()), map[string]interface{}{"changeset": changeset})
+				return nil, fmt.Errorf("the wallet was updated but could not be read back: %s", err)
+			}
+
+			recordApplied(p, nk, "walletUpdate", "wallet:"+userID, json.RawMessage(before.GetWallet()), json.RawMessage(after.GetWallet()))
+
+			return after, nil
+		},
+	}
+)
+
+// walletBalances flattens a wallet or changeset into balances sorted by currency, leaving out non-numeric values
+func walletBalances(wallet map[string]interface{}) []walletBalance {
+	balances := []walletBalance{}
+	var flatten func(prefix string, values map[string]interface{})
+	flatten = func(prefix string, values map[string]interface{}) {
+		for k, v := range values {
+			switch v := v.(type) {
+			case float64:
+				balances = append(balances, walletBalance{prefix + k, v})
+			case int64:
+				balances = append(balances, walletBalance{prefix + k, float64(v)})
+			case int:
+				balances = append(balances, walletBalance{prefix + k, float64(v)})
+			case map[string]interface{}:
+				flatten(prefix+k+".", v)
+			}
+		}
+	}
+	flatten("", wallet)
+	sort.Slice(balances, func(i, j int) bool { return balances[i].currency < balances[j].currency })
+	return balances
+}
+
+// resolveLedgerConnection pages through the ledger of the user in p.Source, as Nakama lists a ledger all at once
+func resolveLedgerConnection(p graphql.ResolveParams) (interface{}, error) {
+
+	nk := p.Context.Value(GRAPHQL_CTX_NAKAMA_MODULE).(runtime.NakamaModule)
+
+	first, after, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := nk.WalletLedgerList(p.Context, p.Source.(*api.User).GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	since, until := unixArg(p, "since"), unixArg(p, "until")
+
+	filtered := []runtime.WalletLedgerItem{}
+	for _, item := range items {
+		if since > 0 && item.GetCreateTime() < int64(since) {
+			continue
+		}
+		if until > 0 && item.GetCreateTime() >= int64(until) {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if filtered[i].GetCreateTime() == filtered[j].GetCreateTime() {
+			return filtered[i].GetID() > filtered[j].GetID()
+		}
+		return filtered[i].GetCreateTime() > filtered[j].GetCreateTime()
+	})
+
+	start := 0
+	if after != "" {
+		afterID, err := base64.RawURLEncoding.DecodeString(after)
+		if err != nil {
+			return nil, fmt.Errorf("malformed cursor `%s`", after)
+		}
+		start = -1
+		for i, item := range filtered {
+			if item.GetID() == string(afterID) {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, fmt.Errorf("cursor `%s` does not point to a ledger item", after)
+		}
+	}
+
+	conn := &connection{}
+
+	for _, item := range filtered[start:] {
+		if len(conn.edges) == first {
+			conn.hasNextPage = true
+			break
+		}
+		conn.edges = append(conn.edges, edge{base64.RawURLEncoding.EncodeToString([]byte(item.GetID())), item})
+	}
+
+	return conn, nil
+}
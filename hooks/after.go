@@ -0,0 +1,754 @@
+// Code generated by genhooks from the runtime.Initializer of Nakama. DO NOT EDIT.
+
+package hooks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc"
+)
+
+// AfterGetAccount is invoked after the server processes the relevant request
+type AfterGetAccount struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Account) error
+}
+
+func (h *AfterGetAccount) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterGetAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Account) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterGetAccount", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out)
+		})
+	})
+}
+
+// AfterUpdateAccount is invoked after the server processes the relevant request
+type AfterUpdateAccount struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) error
+}
+
+func (h *AfterUpdateAccount) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterUpdateAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterUpdateAccount", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterAuthenticateCustom is invoked after the server processes the relevant request
+type AfterAuthenticateCustom struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateCustomRequest) error
+}
+
+func (h *AfterAuthenticateCustom) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterAuthenticateCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateCustomRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterAuthenticateCustom", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterAuthenticateDevice is invoked after the server processes the relevant request
+type AfterAuthenticateDevice struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateDeviceRequest) error
+}
+
+func (h *AfterAuthenticateDevice) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterAuthenticateDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateDeviceRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterAuthenticateDevice", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterAuthenticateEmail is invoked after the server processes the relevant request
+type AfterAuthenticateEmail struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateEmailRequest) error
+}
+
+func (h *AfterAuthenticateEmail) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterAuthenticateEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateEmailRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterAuthenticateEmail", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterAuthenticateFacebook is invoked after the server processes the relevant request
+type AfterAuthenticateFacebook struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateFacebookRequest) error
+}
+
+func (h *AfterAuthenticateFacebook) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterAuthenticateFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateFacebookRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterAuthenticateFacebook", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterAuthenticateGameCenter is invoked after the server processes the relevant request
+type AfterAuthenticateGameCenter struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGameCenterRequest) error
+}
+
+func (h *AfterAuthenticateGameCenter) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterAuthenticateGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGameCenterRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterAuthenticateGameCenter", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterAuthenticateGoogle is invoked after the server processes the relevant request
+type AfterAuthenticateGoogle struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGoogleRequest) error
+}
+
+func (h *AfterAuthenticateGoogle) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterAuthenticateGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGoogleRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterAuthenticateGoogle", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterAuthenticateSteam is invoked after the server processes the relevant request
+type AfterAuthenticateSteam struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateSteamRequest) error
+}
+
+func (h *AfterAuthenticateSteam) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterAuthenticateSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateSteamRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterAuthenticateSteam", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterListChannelMessages is invoked after the server processes the relevant request
+type AfterListChannelMessages struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ChannelMessageList, in *api.ListChannelMessagesRequest) error
+}
+
+func (h *AfterListChannelMessages) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListChannelMessages(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ChannelMessageList, in *api.ListChannelMessagesRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListChannelMessages", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterListFriends is invoked after the server processes the relevant request
+type AfterListFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Friends) error
+}
+
+func (h *AfterListFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Friends) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListFriends", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out)
+		})
+	})
+}
+
+// AfterAddFriends is invoked after the server processes the relevant request
+type AfterAddFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) error
+}
+
+func (h *AfterAddFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterAddFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterAddFriends", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterDeleteFriends is invoked after the server processes the relevant request
+type AfterDeleteFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) error
+}
+
+func (h *AfterDeleteFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterDeleteFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterDeleteFriends", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterBlockFriends is invoked after the server processes the relevant request
+type AfterBlockFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) error
+}
+
+func (h *AfterBlockFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterBlockFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterBlockFriends", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterImportFacebookFriends is invoked after the server processes the relevant request
+type AfterImportFacebookFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) error
+}
+
+func (h *AfterImportFacebookFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterImportFacebookFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterImportFacebookFriends", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterCreateGroup is invoked after the server processes the relevant request
+type AfterCreateGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Group, in *api.CreateGroupRequest) error
+}
+
+func (h *AfterCreateGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterCreateGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Group, in *api.CreateGroupRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterCreateGroup", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterUpdateGroup is invoked after the server processes the relevant request
+type AfterUpdateGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) error
+}
+
+func (h *AfterUpdateGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterUpdateGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterUpdateGroup", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterDeleteGroup is invoked after the server processes the relevant request
+type AfterDeleteGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) error
+}
+
+func (h *AfterDeleteGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterDeleteGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterDeleteGroup", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterJoinGroup is invoked after the server processes the relevant request
+type AfterJoinGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) error
+}
+
+func (h *AfterJoinGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterJoinGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterJoinGroup", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterLeaveGroup is invoked after the server processes the relevant request
+type AfterLeaveGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) error
+}
+
+func (h *AfterLeaveGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterLeaveGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterLeaveGroup", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterAddGroupUsers is invoked after the server processes the relevant request
+type AfterAddGroupUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) error
+}
+
+func (h *AfterAddGroupUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterAddGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterAddGroupUsers", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterKickGroupUsers is invoked after the server processes the relevant request
+type AfterKickGroupUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) error
+}
+
+func (h *AfterKickGroupUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterKickGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterKickGroupUsers", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterPromoteGroupUsers is invoked after the server processes the relevant request
+type AfterPromoteGroupUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) error
+}
+
+func (h *AfterPromoteGroupUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterPromoteGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterPromoteGroupUsers", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterListGroupUsers is invoked after the server processes the relevant request
+type AfterListGroupUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupUserList, in *api.ListGroupUsersRequest) error
+}
+
+func (h *AfterListGroupUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupUserList, in *api.ListGroupUsersRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListGroupUsers", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterListUserGroups is invoked after the server processes the relevant request
+type AfterListUserGroups struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.UserGroupList, in *api.ListUserGroupsRequest) error
+}
+
+func (h *AfterListUserGroups) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListUserGroups(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.UserGroupList, in *api.ListUserGroupsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListUserGroups", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterListGroups is invoked after the server processes the relevant request
+type AfterListGroups struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupList, in *api.ListGroupsRequest) error
+}
+
+func (h *AfterListGroups) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListGroups(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupList, in *api.ListGroupsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListGroups", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterDeleteLeaderboardRecord is invoked after the server processes the relevant request
+type AfterDeleteLeaderboardRecord struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) error
+}
+
+func (h *AfterDeleteLeaderboardRecord) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterDeleteLeaderboardRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterDeleteLeaderboardRecord", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterListLeaderboardRecords is invoked after the server processes the relevant request
+type AfterListLeaderboardRecords struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsRequest) error
+}
+
+func (h *AfterListLeaderboardRecords) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListLeaderboardRecords(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListLeaderboardRecords", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterWriteLeaderboardRecord is invoked after the server processes the relevant request
+type AfterWriteLeaderboardRecord struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteLeaderboardRecordRequest) error
+}
+
+func (h *AfterWriteLeaderboardRecord) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterWriteLeaderboardRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteLeaderboardRecordRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterWriteLeaderboardRecord", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterListLeaderboardRecordsAroundOwner is invoked after the server processes the relevant request
+type AfterListLeaderboardRecordsAroundOwner struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsAroundOwnerRequest) error
+}
+
+func (h *AfterListLeaderboardRecordsAroundOwner) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListLeaderboardRecordsAroundOwner(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsAroundOwnerRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListLeaderboardRecordsAroundOwner", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterLinkCustom is invoked after the server processes the relevant request
+type AfterLinkCustom struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error
+}
+
+func (h *AfterLinkCustom) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterLinkCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterLinkCustom", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterLinkDevice is invoked after the server processes the relevant request
+type AfterLinkDevice struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error
+}
+
+func (h *AfterLinkDevice) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterLinkDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterLinkDevice", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterLinkEmail is invoked after the server processes the relevant request
+type AfterLinkEmail struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error
+}
+
+func (h *AfterLinkEmail) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterLinkEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterLinkEmail", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterLinkFacebook is invoked after the server processes the relevant request
+type AfterLinkFacebook struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) error
+}
+
+func (h *AfterLinkFacebook) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterLinkFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterLinkFacebook", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterLinkGameCenter is invoked after the server processes the relevant request
+type AfterLinkGameCenter struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error
+}
+
+func (h *AfterLinkGameCenter) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterLinkGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterLinkGameCenter", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterLinkGoogle is invoked after the server processes the relevant request
+type AfterLinkGoogle struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error
+}
+
+func (h *AfterLinkGoogle) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterLinkGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterLinkGoogle", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterLinkSteam is invoked after the server processes the relevant request
+type AfterLinkSteam struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) error
+}
+
+func (h *AfterLinkSteam) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterLinkSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterLinkSteam", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterListMatches is invoked after the server processes the relevant request
+type AfterListMatches struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.MatchList, in *api.ListMatchesRequest) error
+}
+
+func (h *AfterListMatches) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListMatches(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.MatchList, in *api.ListMatchesRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListMatches", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterListNotifications is invoked after the server processes the relevant request
+type AfterListNotifications struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.NotificationList, in *api.ListNotificationsRequest) error
+}
+
+func (h *AfterListNotifications) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListNotifications(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.NotificationList, in *api.ListNotificationsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListNotifications", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterDeleteNotification is invoked after the server processes the relevant request
+type AfterDeleteNotification struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) error
+}
+
+func (h *AfterDeleteNotification) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterDeleteNotification(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterDeleteNotification", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterListStorageObjects is invoked after the server processes the relevant request
+type AfterListStorageObjects struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectList, in *api.ListStorageObjectsRequest) error
+}
+
+func (h *AfterListStorageObjects) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectList, in *api.ListStorageObjectsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListStorageObjects", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterReadStorageObjects is invoked after the server processes the relevant request
+type AfterReadStorageObjects struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjects, in *api.ReadStorageObjectsRequest) error
+}
+
+func (h *AfterReadStorageObjects) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterReadStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjects, in *api.ReadStorageObjectsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterReadStorageObjects", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterWriteStorageObjects is invoked after the server processes the relevant request
+type AfterWriteStorageObjects struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectAcks, in *api.WriteStorageObjectsRequest) error
+}
+
+func (h *AfterWriteStorageObjects) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterWriteStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectAcks, in *api.WriteStorageObjectsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterWriteStorageObjects", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterDeleteStorageObjects is invoked after the server processes the relevant request
+type AfterDeleteStorageObjects struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) error
+}
+
+func (h *AfterDeleteStorageObjects) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterDeleteStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterDeleteStorageObjects", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterJoinTournament is invoked after the server processes the relevant request
+type AfterJoinTournament struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) error
+}
+
+func (h *AfterJoinTournament) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterJoinTournament(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterJoinTournament", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterListTournamentRecords is invoked after the server processes the relevant request
+type AfterListTournamentRecords struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsRequest) error
+}
+
+func (h *AfterListTournamentRecords) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListTournamentRecords(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListTournamentRecords", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterListTournaments is invoked after the server processes the relevant request
+type AfterListTournaments struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentList, in *api.ListTournamentsRequest) error
+}
+
+func (h *AfterListTournaments) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListTournaments(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentList, in *api.ListTournamentsRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListTournaments", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterWriteTournamentRecord is invoked after the server processes the relevant request
+type AfterWriteTournamentRecord struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteTournamentRecordRequest) error
+}
+
+func (h *AfterWriteTournamentRecord) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterWriteTournamentRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteTournamentRecordRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterWriteTournamentRecord", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterListTournamentRecordsAroundOwner is invoked after the server processes the relevant request
+type AfterListTournamentRecordsAroundOwner struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsAroundOwnerRequest) error
+}
+
+func (h *AfterListTournamentRecordsAroundOwner) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterListTournamentRecordsAroundOwner(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsAroundOwnerRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterListTournamentRecordsAroundOwner", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+// AfterUnlinkCustom is invoked after the server processes the relevant request
+type AfterUnlinkCustom struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error
+}
+
+func (h *AfterUnlinkCustom) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterUnlinkCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterUnlinkCustom", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterUnlinkDevice is invoked after the server processes the relevant request
+type AfterUnlinkDevice struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error
+}
+
+func (h *AfterUnlinkDevice) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterUnlinkDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterUnlinkDevice", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterUnlinkEmail is invoked after the server processes the relevant request
+type AfterUnlinkEmail struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error
+}
+
+func (h *AfterUnlinkEmail) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterUnlinkEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterUnlinkEmail", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterUnlinkFacebook is invoked after the server processes the relevant request
+type AfterUnlinkFacebook struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) error
+}
+
+func (h *AfterUnlinkFacebook) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterUnlinkFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterUnlinkFacebook", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterUnlinkGameCenter is invoked after the server processes the relevant request
+type AfterUnlinkGameCenter struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error
+}
+
+func (h *AfterUnlinkGameCenter) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterUnlinkGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterUnlinkGameCenter", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterUnlinkGoogle is invoked after the server processes the relevant request
+type AfterUnlinkGoogle struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error
+}
+
+func (h *AfterUnlinkGoogle) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterUnlinkGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterUnlinkGoogle", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterUnlinkSteam is invoked after the server processes the relevant request
+type AfterUnlinkSteam struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) error
+}
+
+func (h *AfterUnlinkSteam) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterUnlinkSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterUnlinkSteam", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+// AfterGetUsers is invoked after the server processes the relevant request
+type AfterGetUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Users, in *api.GetUsersRequest) error
+}
+
+func (h *AfterGetUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterGetUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Users, in *api.GetUsersRequest) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterGetUsers", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, out, in)
+		})
+	})
+}
@@ -0,0 +1,121 @@
+// Code generated by genhooks from the runtime.Initializer of Nakama. DO NOT EDIT.
+
+package hooks
+
+// allHooks lists a hook of each type generated, without handlers
+var allHooks = []Hook{
+	&BeforeGetAccount{},
+	&BeforeUpdateAccount{},
+	&BeforeAuthenticateCustom{},
+	&BeforeAuthenticateDevice{},
+	&BeforeAuthenticateEmail{},
+	&BeforeAuthenticateFacebook{},
+	&BeforeAuthenticateGameCenter{},
+	&BeforeAuthenticateGoogle{},
+	&BeforeAuthenticateSteam{},
+	&BeforeListChannelMessages{},
+	&BeforeListFriends{},
+	&BeforeAddFriends{},
+	&BeforeDeleteFriends{},
+	&BeforeBlockFriends{},
+	&BeforeImportFacebookFriends{},
+	&BeforeCreateGroup{},
+	&BeforeUpdateGroup{},
+	&BeforeDeleteGroup{},
+	&BeforeJoinGroup{},
+	&BeforeLeaveGroup{},
+	&BeforeAddGroupUsers{},
+	&BeforeKickGroupUsers{},
+	&BeforePromoteGroupUsers{},
+	&BeforeListGroupUsers{},
+	&BeforeListUserGroups{},
+	&BeforeListGroups{},
+	&BeforeDeleteLeaderboardRecord{},
+	&BeforeListLeaderboardRecords{},
+	&BeforeWriteLeaderboardRecord{},
+	&BeforeListLeaderboardRecordsAroundOwner{},
+	&BeforeLinkCustom{},
+	&BeforeLinkDevice{},
+	&BeforeLinkEmail{},
+	&BeforeLinkFacebook{},
+	&BeforeLinkGameCenter{},
+	&BeforeLinkGoogle{},
+	&BeforeLinkSteam{},
+	&BeforeListMatches{},
+	&BeforeListNotifications{},
+	&BeforeDeleteNotification{},
+	&BeforeListStorageObjects{},
+	&BeforeReadStorageObjects{},
+	&BeforeWriteStorageObjects{},
+	&BeforeDeleteStorageObjects{},
+	&BeforeJoinTournament{},
+	&BeforeListTournamentRecords{},
+	&BeforeListTournaments{},
+	&BeforeWriteTournamentRecord{},
+	&BeforeListTournamentRecordsAroundOwner{},
+	&BeforeUnlinkCustom{},
+	&BeforeUnlinkDevice{},
+	&BeforeUnlinkEmail{},
+	&BeforeUnlinkFacebook{},
+	&BeforeUnlinkGameCenter{},
+	&BeforeUnlinkGoogle{},
+	&BeforeUnlinkSteam{},
+	&BeforeGetUsers{},
+	&AfterGetAccount{},
+	&AfterUpdateAccount{},
+	&AfterAuthenticateCustom{},
+	&AfterAuthenticateDevice{},
+	&AfterAuthenticateEmail{},
+	&AfterAuthenticateFacebook{},
+	&AfterAuthenticateGameCenter{},
+	&AfterAuthenticateGoogle{},
+	&AfterAuthenticateSteam{},
+	&AfterListChannelMessages{},
+	&AfterListFriends{},
+	&AfterAddFriends{},
+	&AfterDeleteFriends{},
+	&AfterBlockFriends{},
+	&AfterImportFacebookFriends{},
+	&AfterCreateGroup{},
+	&AfterUpdateGroup{},
+	&AfterDeleteGroup{},
+	&AfterJoinGroup{},
+	&AfterLeaveGroup{},
+	&AfterAddGroupUsers{},
+	&AfterKickGroupUsers{},
+	&AfterPromoteGroupUsers{},
+	&AfterListGroupUsers{},
+	&AfterListUserGroups{},
+	&AfterListGroups{},
+	&AfterDeleteLeaderboardRecord{},
+	&AfterListLeaderboardRecords{},
+	&AfterWriteLeaderboardRecord{},
+	&AfterListLeaderboardRecordsAroundOwner{},
+	&AfterLinkCustom{},
+	&AfterLinkDevice{},
+	&AfterLinkEmail{},
+	&AfterLinkFacebook{},
+	&AfterLinkGameCenter{},
+	&AfterLinkGoogle{},
+	&AfterLinkSteam{},
+	&AfterListMatches{},
+	&AfterListNotifications{},
+	&AfterDeleteNotification{},
+	&AfterListStorageObjects{},
+	&AfterReadStorageObjects{},
+	&AfterWriteStorageObjects{},
+	&AfterDeleteStorageObjects{},
+	&AfterJoinTournament{},
+	&AfterListTournamentRecords{},
+	&AfterListTournaments{},
+	&AfterWriteTournamentRecord{},
+	&AfterListTournamentRecordsAroundOwner{},
+	&AfterUnlinkCustom{},
+	&AfterUnlinkDevice{},
+	&AfterUnlinkEmail{},
+	&AfterUnlinkFacebook{},
+	&AfterUnlinkGameCenter{},
+	&AfterUnlinkGoogle{},
+	&AfterUnlinkSteam{},
+	&AfterGetUsers{},
+}
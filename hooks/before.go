@@ -0,0 +1,919 @@
+// Code generated by genhooks from the runtime.Initializer of Nakama. DO NOT EDIT.
+
+package hooks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc"
+)
+
+// BeforeGetAccount is invoked when the server receives the relevant request
+type BeforeGetAccount struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error
+}
+
+func (h *BeforeGetAccount) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeGetAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error {
+		return rpc.Invoke(ctx, logger, mw, "BeforeGetAccount", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk)
+		})
+	})
+}
+
+// BeforeUpdateAccount is invoked when the server receives the relevant request
+type BeforeUpdateAccount struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) (*api.UpdateAccountRequest, error)
+}
+
+func (h *BeforeUpdateAccount) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeUpdateAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) (*api.UpdateAccountRequest, error) {
+		var result *api.UpdateAccountRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeUpdateAccount", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeAuthenticateCustom is invoked when the server receives the relevant request
+type BeforeAuthenticateCustom struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateCustomRequest) (*api.AuthenticateCustomRequest, error)
+}
+
+func (h *BeforeAuthenticateCustom) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeAuthenticateCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateCustomRequest) (*api.AuthenticateCustomRequest, error) {
+		var result *api.AuthenticateCustomRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeAuthenticateCustom", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeAuthenticateDevice is invoked when the server receives the relevant request
+type BeforeAuthenticateDevice struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateDeviceRequest) (*api.AuthenticateDeviceRequest, error)
+}
+
+func (h *BeforeAuthenticateDevice) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeAuthenticateDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateDeviceRequest) (*api.AuthenticateDeviceRequest, error) {
+		var result *api.AuthenticateDeviceRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeAuthenticateDevice", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeAuthenticateEmail is invoked when the server receives the relevant request
+type BeforeAuthenticateEmail struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateEmailRequest) (*api.AuthenticateEmailRequest, error)
+}
+
+func (h *BeforeAuthenticateEmail) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeAuthenticateEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateEmailRequest) (*api.AuthenticateEmailRequest, error) {
+		var result *api.AuthenticateEmailRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeAuthenticateEmail", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeAuthenticateFacebook is invoked when the server receives the relevant request
+type BeforeAuthenticateFacebook struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateFacebookRequest) (*api.AuthenticateFacebookRequest, error)
+}
+
+func (h *BeforeAuthenticateFacebook) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeAuthenticateFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateFacebookRequest) (*api.AuthenticateFacebookRequest, error) {
+		var result *api.AuthenticateFacebookRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeAuthenticateFacebook", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeAuthenticateGameCenter is invoked when the server receives the relevant request
+type BeforeAuthenticateGameCenter struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGameCenterRequest) (*api.AuthenticateGameCenterRequest, error)
+}
+
+func (h *BeforeAuthenticateGameCenter) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeAuthenticateGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGameCenterRequest) (*api.AuthenticateGameCenterRequest, error) {
+		var result *api.AuthenticateGameCenterRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeAuthenticateGameCenter", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeAuthenticateGoogle is invoked when the server receives the relevant request
+type BeforeAuthenticateGoogle struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGoogleRequest) (*api.AuthenticateGoogleRequest, error)
+}
+
+func (h *BeforeAuthenticateGoogle) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeAuthenticateGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGoogleRequest) (*api.AuthenticateGoogleRequest, error) {
+		var result *api.AuthenticateGoogleRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeAuthenticateGoogle", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeAuthenticateSteam is invoked when the server receives the relevant request
+type BeforeAuthenticateSteam struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateSteamRequest) (*api.AuthenticateSteamRequest, error)
+}
+
+func (h *BeforeAuthenticateSteam) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeAuthenticateSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateSteamRequest) (*api.AuthenticateSteamRequest, error) {
+		var result *api.AuthenticateSteamRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeAuthenticateSteam", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListChannelMessages is invoked when the server receives the relevant request
+type BeforeListChannelMessages struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListChannelMessagesRequest) (*api.ListChannelMessagesRequest, error)
+}
+
+func (h *BeforeListChannelMessages) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListChannelMessages(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListChannelMessagesRequest) (*api.ListChannelMessagesRequest, error) {
+		var result *api.ListChannelMessagesRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListChannelMessages", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListFriends is invoked when the server receives the relevant request
+type BeforeListFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error
+}
+
+func (h *BeforeListFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error {
+		return rpc.Invoke(ctx, logger, mw, "BeforeListFriends", func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk)
+		})
+	})
+}
+
+// BeforeAddFriends is invoked when the server receives the relevant request
+type BeforeAddFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) (*api.AddFriendsRequest, error)
+}
+
+func (h *BeforeAddFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeAddFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) (*api.AddFriendsRequest, error) {
+		var result *api.AddFriendsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeAddFriends", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeDeleteFriends is invoked when the server receives the relevant request
+type BeforeDeleteFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) (*api.DeleteFriendsRequest, error)
+}
+
+func (h *BeforeDeleteFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeDeleteFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) (*api.DeleteFriendsRequest, error) {
+		var result *api.DeleteFriendsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeDeleteFriends", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeBlockFriends is invoked when the server receives the relevant request
+type BeforeBlockFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) (*api.BlockFriendsRequest, error)
+}
+
+func (h *BeforeBlockFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeBlockFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) (*api.BlockFriendsRequest, error) {
+		var result *api.BlockFriendsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeBlockFriends", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeImportFacebookFriends is invoked when the server receives the relevant request
+type BeforeImportFacebookFriends struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) (*api.ImportFacebookFriendsRequest, error)
+}
+
+func (h *BeforeImportFacebookFriends) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeImportFacebookFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) (*api.ImportFacebookFriendsRequest, error) {
+		var result *api.ImportFacebookFriendsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeImportFacebookFriends", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeCreateGroup is invoked when the server receives the relevant request
+type BeforeCreateGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.CreateGroupRequest) (*api.CreateGroupRequest, error)
+}
+
+func (h *BeforeCreateGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeCreateGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.CreateGroupRequest) (*api.CreateGroupRequest, error) {
+		var result *api.CreateGroupRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeCreateGroup", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeUpdateGroup is invoked when the server receives the relevant request
+type BeforeUpdateGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) (*api.UpdateGroupRequest, error)
+}
+
+func (h *BeforeUpdateGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeUpdateGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) (*api.UpdateGroupRequest, error) {
+		var result *api.UpdateGroupRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeUpdateGroup", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeDeleteGroup is invoked when the server receives the relevant request
+type BeforeDeleteGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) (*api.DeleteGroupRequest, error)
+}
+
+func (h *BeforeDeleteGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeDeleteGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) (*api.DeleteGroupRequest, error) {
+		var result *api.DeleteGroupRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeDeleteGroup", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeJoinGroup is invoked when the server receives the relevant request
+type BeforeJoinGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) (*api.JoinGroupRequest, error)
+}
+
+func (h *BeforeJoinGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeJoinGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) (*api.JoinGroupRequest, error) {
+		var result *api.JoinGroupRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeJoinGroup", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeLeaveGroup is invoked when the server receives the relevant request
+type BeforeLeaveGroup struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) (*api.LeaveGroupRequest, error)
+}
+
+func (h *BeforeLeaveGroup) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeLeaveGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) (*api.LeaveGroupRequest, error) {
+		var result *api.LeaveGroupRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeLeaveGroup", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeAddGroupUsers is invoked when the server receives the relevant request
+type BeforeAddGroupUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) (*api.AddGroupUsersRequest, error)
+}
+
+func (h *BeforeAddGroupUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeAddGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) (*api.AddGroupUsersRequest, error) {
+		var result *api.AddGroupUsersRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeAddGroupUsers", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeKickGroupUsers is invoked when the server receives the relevant request
+type BeforeKickGroupUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) (*api.KickGroupUsersRequest, error)
+}
+
+func (h *BeforeKickGroupUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeKickGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) (*api.KickGroupUsersRequest, error) {
+		var result *api.KickGroupUsersRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeKickGroupUsers", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforePromoteGroupUsers is invoked when the server receives the relevant request
+type BeforePromoteGroupUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) (*api.PromoteGroupUsersRequest, error)
+}
+
+func (h *BeforePromoteGroupUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforePromoteGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) (*api.PromoteGroupUsersRequest, error) {
+		var result *api.PromoteGroupUsersRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforePromoteGroupUsers", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListGroupUsers is invoked when the server receives the relevant request
+type BeforeListGroupUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupUsersRequest) (*api.ListGroupUsersRequest, error)
+}
+
+func (h *BeforeListGroupUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupUsersRequest) (*api.ListGroupUsersRequest, error) {
+		var result *api.ListGroupUsersRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListGroupUsers", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListUserGroups is invoked when the server receives the relevant request
+type BeforeListUserGroups struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListUserGroupsRequest) (*api.ListUserGroupsRequest, error)
+}
+
+func (h *BeforeListUserGroups) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListUserGroups(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListUserGroupsRequest) (*api.ListUserGroupsRequest, error) {
+		var result *api.ListUserGroupsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListUserGroups", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListGroups is invoked when the server receives the relevant request
+type BeforeListGroups struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupsRequest) (*api.ListGroupsRequest, error)
+}
+
+func (h *BeforeListGroups) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListGroups(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupsRequest) (*api.ListGroupsRequest, error) {
+		var result *api.ListGroupsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListGroups", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeDeleteLeaderboardRecord is invoked when the server receives the relevant request
+type BeforeDeleteLeaderboardRecord struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) (*api.DeleteLeaderboardRecordRequest, error)
+}
+
+func (h *BeforeDeleteLeaderboardRecord) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeDeleteLeaderboardRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) (*api.DeleteLeaderboardRecordRequest, error) {
+		var result *api.DeleteLeaderboardRecordRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeDeleteLeaderboardRecord", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListLeaderboardRecords is invoked when the server receives the relevant request
+type BeforeListLeaderboardRecords struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsRequest) (*api.ListLeaderboardRecordsRequest, error)
+}
+
+func (h *BeforeListLeaderboardRecords) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListLeaderboardRecords(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsRequest) (*api.ListLeaderboardRecordsRequest, error) {
+		var result *api.ListLeaderboardRecordsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListLeaderboardRecords", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeWriteLeaderboardRecord is invoked when the server receives the relevant request
+type BeforeWriteLeaderboardRecord struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteLeaderboardRecordRequest) (*api.WriteLeaderboardRecordRequest, error)
+}
+
+func (h *BeforeWriteLeaderboardRecord) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeWriteLeaderboardRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteLeaderboardRecordRequest) (*api.WriteLeaderboardRecordRequest, error) {
+		var result *api.WriteLeaderboardRecordRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeWriteLeaderboardRecord", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListLeaderboardRecordsAroundOwner is invoked when the server receives the relevant request
+type BeforeListLeaderboardRecordsAroundOwner struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsAroundOwnerRequest) (*api.ListLeaderboardRecordsAroundOwnerRequest, error)
+}
+
+func (h *BeforeListLeaderboardRecordsAroundOwner) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListLeaderboardRecordsAroundOwner(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsAroundOwnerRequest) (*api.ListLeaderboardRecordsAroundOwnerRequest, error) {
+		var result *api.ListLeaderboardRecordsAroundOwnerRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListLeaderboardRecordsAroundOwner", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeLinkCustom is invoked when the server receives the relevant request
+type BeforeLinkCustom struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error)
+}
+
+func (h *BeforeLinkCustom) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeLinkCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error) {
+		var result *api.AccountCustom
+		err := rpc.Invoke(ctx, logger, mw, "BeforeLinkCustom", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeLinkDevice is invoked when the server receives the relevant request
+type BeforeLinkDevice struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error)
+}
+
+func (h *BeforeLinkDevice) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeLinkDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error) {
+		var result *api.AccountDevice
+		err := rpc.Invoke(ctx, logger, mw, "BeforeLinkDevice", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeLinkEmail is invoked when the server receives the relevant request
+type BeforeLinkEmail struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error)
+}
+
+func (h *BeforeLinkEmail) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeLinkEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error) {
+		var result *api.AccountEmail
+		err := rpc.Invoke(ctx, logger, mw, "BeforeLinkEmail", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeLinkFacebook is invoked when the server receives the relevant request
+type BeforeLinkFacebook struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) (*api.LinkFacebookRequest, error)
+}
+
+func (h *BeforeLinkFacebook) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeLinkFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) (*api.LinkFacebookRequest, error) {
+		var result *api.LinkFacebookRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeLinkFacebook", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeLinkGameCenter is invoked when the server receives the relevant request
+type BeforeLinkGameCenter struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error)
+}
+
+func (h *BeforeLinkGameCenter) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeLinkGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error) {
+		var result *api.AccountGameCenter
+		err := rpc.Invoke(ctx, logger, mw, "BeforeLinkGameCenter", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeLinkGoogle is invoked when the server receives the relevant request
+type BeforeLinkGoogle struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error)
+}
+
+func (h *BeforeLinkGoogle) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeLinkGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error) {
+		var result *api.AccountGoogle
+		err := rpc.Invoke(ctx, logger, mw, "BeforeLinkGoogle", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeLinkSteam is invoked when the server receives the relevant request
+type BeforeLinkSteam struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) (*api.AccountSteam, error)
+}
+
+func (h *BeforeLinkSteam) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeLinkSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) (*api.AccountSteam, error) {
+		var result *api.AccountSteam
+		err := rpc.Invoke(ctx, logger, mw, "BeforeLinkSteam", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListMatches is invoked when the server receives the relevant request
+type BeforeListMatches struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListMatchesRequest) (*api.ListMatchesRequest, error)
+}
+
+func (h *BeforeListMatches) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListMatches(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListMatchesRequest) (*api.ListMatchesRequest, error) {
+		var result *api.ListMatchesRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListMatches", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListNotifications is invoked when the server receives the relevant request
+type BeforeListNotifications struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListNotificationsRequest) (*api.ListNotificationsRequest, error)
+}
+
+func (h *BeforeListNotifications) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListNotifications(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListNotificationsRequest) (*api.ListNotificationsRequest, error) {
+		var result *api.ListNotificationsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListNotifications", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeDeleteNotification is invoked when the server receives the relevant request
+type BeforeDeleteNotification struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) (*api.DeleteNotificationsRequest, error)
+}
+
+func (h *BeforeDeleteNotification) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeDeleteNotification(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) (*api.DeleteNotificationsRequest, error) {
+		var result *api.DeleteNotificationsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeDeleteNotification", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListStorageObjects is invoked when the server receives the relevant request
+type BeforeListStorageObjects struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListStorageObjectsRequest) (*api.ListStorageObjectsRequest, error)
+}
+
+func (h *BeforeListStorageObjects) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListStorageObjectsRequest) (*api.ListStorageObjectsRequest, error) {
+		var result *api.ListStorageObjectsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListStorageObjects", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeReadStorageObjects is invoked when the server receives the relevant request
+type BeforeReadStorageObjects struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ReadStorageObjectsRequest) (*api.ReadStorageObjectsRequest, error)
+}
+
+func (h *BeforeReadStorageObjects) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeReadStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ReadStorageObjectsRequest) (*api.ReadStorageObjectsRequest, error) {
+		var result *api.ReadStorageObjectsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeReadStorageObjects", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeWriteStorageObjects is invoked when the server receives the relevant request
+type BeforeWriteStorageObjects struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteStorageObjectsRequest) (*api.WriteStorageObjectsRequest, error)
+}
+
+func (h *BeforeWriteStorageObjects) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeWriteStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteStorageObjectsRequest) (*api.WriteStorageObjectsRequest, error) {
+		var result *api.WriteStorageObjectsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeWriteStorageObjects", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeDeleteStorageObjects is invoked when the server receives the relevant request
+type BeforeDeleteStorageObjects struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) (*api.DeleteStorageObjectsRequest, error)
+}
+
+func (h *BeforeDeleteStorageObjects) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeDeleteStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) (*api.DeleteStorageObjectsRequest, error) {
+		var result *api.DeleteStorageObjectsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeDeleteStorageObjects", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeJoinTournament is invoked when the server receives the relevant request
+type BeforeJoinTournament struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) (*api.JoinTournamentRequest, error)
+}
+
+func (h *BeforeJoinTournament) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeJoinTournament(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) (*api.JoinTournamentRequest, error) {
+		var result *api.JoinTournamentRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeJoinTournament", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListTournamentRecords is invoked when the server receives the relevant request
+type BeforeListTournamentRecords struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsRequest) (*api.ListTournamentRecordsRequest, error)
+}
+
+func (h *BeforeListTournamentRecords) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListTournamentRecords(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsRequest) (*api.ListTournamentRecordsRequest, error) {
+		var result *api.ListTournamentRecordsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListTournamentRecords", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListTournaments is invoked when the server receives the relevant request
+type BeforeListTournaments struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentsRequest) (*api.ListTournamentsRequest, error)
+}
+
+func (h *BeforeListTournaments) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListTournaments(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentsRequest) (*api.ListTournamentsRequest, error) {
+		var result *api.ListTournamentsRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListTournaments", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeWriteTournamentRecord is invoked when the server receives the relevant request
+type BeforeWriteTournamentRecord struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteTournamentRecordRequest) (*api.WriteTournamentRecordRequest, error)
+}
+
+func (h *BeforeWriteTournamentRecord) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeWriteTournamentRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteTournamentRecordRequest) (*api.WriteTournamentRecordRequest, error) {
+		var result *api.WriteTournamentRecordRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeWriteTournamentRecord", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeListTournamentRecordsAroundOwner is invoked when the server receives the relevant request
+type BeforeListTournamentRecordsAroundOwner struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsAroundOwnerRequest) (*api.ListTournamentRecordsAroundOwnerRequest, error)
+}
+
+func (h *BeforeListTournamentRecordsAroundOwner) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeListTournamentRecordsAroundOwner(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsAroundOwnerRequest) (*api.ListTournamentRecordsAroundOwnerRequest, error) {
+		var result *api.ListTournamentRecordsAroundOwnerRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeListTournamentRecordsAroundOwner", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeUnlinkCustom is invoked when the server receives the relevant request
+type BeforeUnlinkCustom struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error)
+}
+
+func (h *BeforeUnlinkCustom) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeUnlinkCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error) {
+		var result *api.AccountCustom
+		err := rpc.Invoke(ctx, logger, mw, "BeforeUnlinkCustom", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeUnlinkDevice is invoked when the server receives the relevant request
+type BeforeUnlinkDevice struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error)
+}
+
+func (h *BeforeUnlinkDevice) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeUnlinkDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error) {
+		var result *api.AccountDevice
+		err := rpc.Invoke(ctx, logger, mw, "BeforeUnlinkDevice", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeUnlinkEmail is invoked when the server receives the relevant request
+type BeforeUnlinkEmail struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error)
+}
+
+func (h *BeforeUnlinkEmail) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeUnlinkEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error) {
+		var result *api.AccountEmail
+		err := rpc.Invoke(ctx, logger, mw, "BeforeUnlinkEmail", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeUnlinkFacebook is invoked when the server receives the relevant request
+type BeforeUnlinkFacebook struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) (*api.AccountFacebook, error)
+}
+
+func (h *BeforeUnlinkFacebook) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeUnlinkFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) (*api.AccountFacebook, error) {
+		var result *api.AccountFacebook
+		err := rpc.Invoke(ctx, logger, mw, "BeforeUnlinkFacebook", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeUnlinkGameCenter is invoked when the server receives the relevant request
+type BeforeUnlinkGameCenter struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error)
+}
+
+func (h *BeforeUnlinkGameCenter) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeUnlinkGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error) {
+		var result *api.AccountGameCenter
+		err := rpc.Invoke(ctx, logger, mw, "BeforeUnlinkGameCenter", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeUnlinkGoogle is invoked when the server receives the relevant request
+type BeforeUnlinkGoogle struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error)
+}
+
+func (h *BeforeUnlinkGoogle) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeUnlinkGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error) {
+		var result *api.AccountGoogle
+		err := rpc.Invoke(ctx, logger, mw, "BeforeUnlinkGoogle", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeUnlinkSteam is invoked when the server receives the relevant request
+type BeforeUnlinkSteam struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) (*api.AccountSteam, error)
+}
+
+func (h *BeforeUnlinkSteam) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeUnlinkSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) (*api.AccountSteam, error) {
+		var result *api.AccountSteam
+		err := rpc.Invoke(ctx, logger, mw, "BeforeUnlinkSteam", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+// BeforeGetUsers is invoked when the server receives the relevant request
+type BeforeGetUsers struct {
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.GetUsersRequest) (*api.GetUsersRequest, error)
+}
+
+func (h *BeforeGetUsers) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeGetUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.GetUsersRequest) (*api.GetUsersRequest, error) {
+		var result *api.GetUsersRequest
+		err := rpc.Invoke(ctx, logger, mw, "BeforeGetUsers", func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
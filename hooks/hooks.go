@@ -0,0 +1,62 @@
+// Package hooks wraps the before and after hooks of Nakama in types registered with rpc middleware, before.go and
+// after.go being generated from the runtime.Initializer of the Nakama version the module requires
+package hooks
+
+//go:generate go run ./internal/genhooks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/heroiclabs/nakama/rtapi"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc"
+)
+
+// Hook is a before or after hook that can be registered on a Nakama runtime.Initializer
+type Hook interface {
+	Register(init runtime.Initializer, mw rpc.Middleware) error
+}
+
+// BeforeRt is invoked when the server receives the realtime message named ID
+type BeforeRt struct {
+	ID      string
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) (*rtapi.Envelope, error)
+}
+
+func (h *BeforeRt) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterBeforeRt(h.ID, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) (*rtapi.Envelope, error) {
+		var result *rtapi.Envelope
+		err := rpc.Invoke(ctx, logger, mw, "BeforeRt"+h.ID, func(ctx context.Context) (err error) {
+			result, err = h.Handler(ctx, logger, db, nk, envelope)
+			return err
+		})
+		return result, err
+	})
+}
+
+// AfterRt is invoked after the server processes the realtime message named ID
+type AfterRt struct {
+	ID      string
+	Handler func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) error
+}
+
+func (h *AfterRt) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.RegisterAfterRt(h.ID, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) error {
+		return rpc.Invoke(ctx, logger, mw, "AfterRt"+h.ID, func(ctx context.Context) error {
+			return h.Handler(ctx, logger, db, nk, envelope)
+		})
+	})
+}
+
+// RegisterHooks registers each of the hooks, wrapping their handlers with the given middleware
+func RegisterHooks(init runtime.Initializer, hooks []Hook, middleware ...rpc.Middleware) error {
+	mw := rpc.Chain(middleware...)
+	for _, hook := range hooks {
+		if err := hook.Register(init, mw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
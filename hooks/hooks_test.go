@@ -0,0 +1,85 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc/rpctest"
+	"github.com/mastern2k3/poseidon/tests/fake"
+)
+
+func TestRegisterHooks(t *testing.T) {
+
+	hooks := append(append([]Hook{}, allHooks...), &BeforeRt{ID: "ChannelJoin"}, &AfterRt{ID: "ChannelJoin"})
+
+	for _, hook := range hooks {
+		name := reflect.TypeOf(hook).Elem().Name()
+		if id := reflect.ValueOf(hook).Elem().FieldByName("ID"); id.IsValid() {
+			name += id.String()
+		}
+
+		t.Run(name, func(t *testing.T) {
+
+			var handled, wrapped bool
+			failure := errors.New("refused")
+
+			handler := reflect.ValueOf(hook).Elem().FieldByName("Handler")
+			handler.Set(reflect.MakeFunc(handler.Type(), func(args []reflect.Value) []reflect.Value {
+				handled = true
+				results := []reflect.Value{reflect.ValueOf(&failure).Elem()}
+				if handler.Type().NumOut() == 2 {
+					// Before hooks with a request hand it back
+					results = append([]reflect.Value{args[len(args)-1]}, results...)
+				}
+				return results
+			}))
+
+			init := rpctest.New(t, fake.NewNakamaModule())
+			mw := func(ctx context.Context, logger runtime.Logger, called string, next func(ctx context.Context) error) error {
+				if called != name {
+					t.Fatalf("expected the middleware to be given `%s` but got `%s`", name, called)
+				}
+				wrapped = true
+				return next(ctx)
+			}
+			if err := RegisterHooks(init, []Hook{hook}, mw); err != nil {
+				t.Fatalf("error while registering: %s", err)
+			}
+
+			fn := reflect.ValueOf(init.Hook(name))
+			if !fn.IsValid() {
+				t.Fatalf("expected the hook to be registered as `%s`", name)
+			}
+
+			args := make([]reflect.Value, fn.Type().NumIn())
+			for i := range args {
+				param := fn.Type().In(i)
+				switch {
+				case i == 0:
+					args[i] = reflect.ValueOf(context.Background())
+				case i == 1:
+					args[i] = reflect.ValueOf(init.Logger)
+				case param.Kind() == reflect.Ptr:
+					args[i] = reflect.New(param.Elem())
+				default:
+					args[i] = reflect.Zero(param)
+				}
+			}
+			results := fn.Call(args)
+
+			if !handled || !wrapped {
+				t.Fatalf("expected the handler to run through the middleware, handled %v, wrapped %v", handled, wrapped)
+			}
+			if err, _ := results[len(results)-1].Interface().(error); err != failure {
+				t.Fatalf("expected the error of the handler but got %v", err)
+			}
+			if len(results) == 2 && results[0].Pointer() != args[len(args)-1].Pointer() {
+				t.Fatalf("expected the request returned by the handler")
+			}
+		})
+	}
+}
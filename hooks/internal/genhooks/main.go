@@ -0,0 +1,332 @@
+// Command genhooks generates the typed before and after hooks from the runtime.Initializer of the Nakama version
+// the module requires, along with the registrations that wrap hooks with rpc middleware and capture them in rpctest.
+// It is run through go generate in the hooks package, writing its files relative to the root of the module.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const nakamaModule = "github.com/heroiclabs/nakama"
+
+// hook is a before or after hook of the runtime.Initializer
+type hook struct {
+	// Name is the name of the hook, as in `BeforeGetAccount`
+	Name string
+	// Before tells before hooks from after hooks
+	Before bool
+	// ID tells hooks registered per message, as in `BeforeRt`, which take an id besides the handler
+	ID bool
+	// Func is the signature of the handler, with the types of the runtime package qualified
+	Func string
+	// Args are the names of the parameters of the handler
+	Args string
+	// Result is the type the handler returns along with an error, if any
+	Result string
+}
+
+func main() {
+
+	root := flag.String("root", "", "the root of the module to write to, found with go list by default")
+	nakama := flag.String("nakama", "", "the source of the Nakama module, found with go list by default")
+	flag.Parse()
+
+	var err error
+	if *root == "" {
+		if *root, err = moduleDir(""); err != nil {
+			log.Fatalf("failed to find the root of the module: %s", err)
+		}
+	}
+	if *nakama == "" {
+		if *nakama, err = moduleDir(nakamaModule); err != nil {
+			log.Fatalf("failed to find the source of `%s`: %s", nakamaModule, err)
+		}
+	}
+
+	files, err := generate(filepath.Join(*nakama, "runtime", "runtime.go"))
+	if err != nil {
+		log.Fatalf("failed to generate the hooks: %s", err)
+	}
+
+	for _, name := range sortedNames(files) {
+		if err := ioutil.WriteFile(filepath.Join(*root, filepath.FromSlash(name)), files[name], 0644); err != nil {
+			log.Fatalf("failed to write %s: %s", name, err)
+		}
+	}
+}
+
+// moduleDir finds the directory of module with go list, or of the main module if module is empty
+func moduleDir(module string) (string, error) {
+	args := []string{"list", "-m", "-f", "{{.Dir}}"}
+	if module != "" {
+		args = append(args, module)
+	}
+	out, err := exec.Command("go", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// generate renders the generated files from the runtime.go of Nakama found at src, keyed by their slash separated
+// paths relative to the root of the module
+func generate(src string) (map[string][]byte, error) {
+
+	hooks, err := parseHooks(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var before, after []hook
+	for _, h := range hooks {
+		switch {
+		case h.ID:
+			// Hooks registered per message have a hand written wrapper in hooks.go
+		case h.Before:
+			before = append(before, h)
+		default:
+			after = append(after, h)
+		}
+	}
+
+	files := map[string][]byte{}
+	for name, data := range map[string]interface{}{
+		"hooks/before.go":      before,
+		"hooks/after.go":       after,
+		"hooks/all_test.go":    append(append([]hook{}, before...), after...),
+		"rpc/hooks.go":         hooks,
+		"rpc/rpctest/hooks.go": hooks,
+	} {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %s", name, err)
+		}
+		source, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to format %s: %s", name, err)
+		}
+		files[name] = source
+	}
+
+	return files, nil
+}
+
+// parseHooks lists the hooks of the runtime.Initializer declared in src, in the order they are declared
+func parseHooks(src string) ([]hook, error) {
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, src, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var initializer *ast.InterfaceType
+	ast.Inspect(file, func(node ast.Node) bool {
+		if spec, ok := node.(*ast.TypeSpec); ok && spec.Name.Name == "Initializer" {
+			initializer, _ = spec.Type.(*ast.InterfaceType)
+		}
+		return initializer == nil
+	})
+	if initializer == nil {
+		return nil, fmt.Errorf("no `Initializer` interface in %s", src)
+	}
+
+	var hooks []hook
+	for _, method := range initializer.Methods.List {
+		if len(method.Names) != 1 {
+			continue
+		}
+		name := strings.TrimPrefix(method.Names[0].Name, "Register")
+		if !strings.HasPrefix(name, "Before") && !strings.HasPrefix(name, "After") {
+			continue
+		}
+
+		params := method.Type.(*ast.FuncType).Params.List
+		fn, ok := params[len(params)-1].Type.(*ast.FuncType)
+		if !ok {
+			return nil, fmt.Errorf("`Register%s` does not take a handler last", name)
+		}
+		fn = qualify(fn).(*ast.FuncType)
+
+		h := hook{
+			Name:   name,
+			Before: strings.HasPrefix(name, "Before"),
+			ID:     len(params) > 1,
+		}
+		if h.Func, err = render(fset, fn); err != nil {
+			return nil, err
+		}
+
+		var args []string
+		for _, param := range fn.Params.List {
+			for _, ident := range param.Names {
+				args = append(args, ident.Name)
+			}
+		}
+		h.Args = strings.Join(args, ", ")
+
+		if fn.Results != nil && len(fn.Results.List) == 2 {
+			if h.Result, err = render(fset, fn.Results.List[0].Type); err != nil {
+				return nil, err
+			}
+		}
+
+		hooks = append(hooks, h)
+	}
+
+	return hooks, nil
+}
+
+// qualify rewrites the types the runtime package declares within expr to be referenced from outside of it
+func qualify(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(e.Name) {
+			return &ast.SelectorExpr{X: ast.NewIdent("runtime"), Sel: ast.NewIdent(e.Name)}
+		}
+	case *ast.StarExpr:
+		e.X = qualify(e.X)
+	case *ast.ArrayType:
+		e.Elt = qualify(e.Elt)
+	case *ast.MapType:
+		e.Key = qualify(e.Key)
+		e.Value = qualify(e.Value)
+	case *ast.FuncType:
+		for _, list := range []*ast.FieldList{e.Params, e.Results} {
+			if list == nil {
+				continue
+			}
+			for _, field := range list.List {
+				field.Type = qualify(field.Type)
+			}
+		}
+	}
+	return expr
+}
+
+func render(fset *token.FileSet, node ast.Node) (string, error) {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func sortedNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+const header = `// Code generated by genhooks from the runtime.Initializer of Nakama. DO NOT EDIT.
+
+`
+
+var templates = template.Must(template.New("").Parse(`
+{{define "imports"}}
+import (
+	"context"
+	"database/sql"
+
+	"github.com/heroiclabs/nakama/api"
+	{{- if .}}
+	"github.com/heroiclabs/nakama/rtapi"
+	{{- end}}
+	"github.com/heroiclabs/nakama/runtime"
+{{end}}
+
+{{define "hook"}}
+{{if .Before}}// {{.Name}} is invoked when the server receives the relevant request
+{{else}}// {{.Name}} is invoked after the server processes the relevant request
+{{end -}}
+type {{.Name}} struct {
+	Handler {{.Func}}
+}
+
+func (h *{{.Name}}) Register(init runtime.Initializer, mw rpc.Middleware) error {
+	return init.Register{{.Name}}({{.Func}} {
+		{{- if .Result}}
+		var result {{.Result}}
+		err := rpc.Invoke(ctx, logger, mw, "{{.Name}}", func(ctx context.Context) (err error) {
+			result, err = h.Handler({{.Args}})
+			return err
+		})
+		return result, err
+		{{- else}}
+		return rpc.Invoke(ctx, logger, mw, "{{.Name}}", func(ctx context.Context) error {
+			return h.Handler({{.Args}})
+		})
+		{{- end}}
+	})
+}
+{{end}}
+
+{{define "hooks"}}` + header + `package hooks
+{{template "imports" false}}
+	"github.com/mastern2k3/poseidon/rpc"
+)
+{{range .}}{{template "hook" .}}{{end}}
+{{end}}
+
+{{define "hooks/before.go"}}{{template "hooks" .}}{{end}}
+
+{{define "hooks/after.go"}}{{template "hooks" .}}{{end}}
+
+{{define "hooks/all_test.go"}}` + header + `package hooks
+
+// allHooks lists a hook of each type generated, without handlers
+var allHooks = []Hook{
+	{{- range .}}
+	&{{.Name}}{},
+	{{- end}}
+}
+{{end}}
+
+{{define "rpc/hooks.go"}}` + header + `package rpc
+{{template "imports" true}})
+{{range .}}
+func (i *middlewareInitializer) Register{{.Name}}({{if .ID}}id string, {{end}}fn {{.Func}}) error {
+	return i.Initializer.Register{{.Name}}({{if .ID}}id, {{end}}{{.Func}} {
+		{{- if .Result}}
+		var result {{.Result}}
+		err := i.middleware(ctx, logger, "{{.Name}}"{{if .ID}}+id{{end}}, func(ctx context.Context) (err error) {
+			result, err = fn({{.Args}})
+			return err
+		})
+		return result, err
+		{{- else}}
+		return i.middleware(ctx, logger, "{{.Name}}"{{if .ID}}+id{{end}}, func(ctx context.Context) error {
+			return fn({{.Args}})
+		})
+		{{- end}}
+	})
+}
+{{end}}
+{{end}}
+
+{{define "rpc/rpctest/hooks.go"}}` + header + `package rpctest
+{{template "imports" true}})
+{{range .}}
+func (i *Initializer) Register{{.Name}}({{if .ID}}id string, {{end}}fn {{.Func}}) error {
+	return i.registerHook("{{.Name}}"{{if .ID}}+id{{end}}, fn)
+}
+{{end}}
+{{end}}
+`))
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerated(t *testing.T) {
+
+	root, err := moduleDir("")
+	if err != nil {
+		t.Fatalf("error while finding the root of the module: %s", err)
+	}
+	nakama, err := moduleDir(nakamaModule)
+	if err != nil {
+		t.Fatalf("error while finding the source of `%s`: %s", nakamaModule, err)
+	}
+
+	files, err := generate(filepath.Join(nakama, "runtime", "runtime.go"))
+	if err != nil {
+		t.Fatalf("error while generating: %s", err)
+	}
+
+	for _, name := range sortedNames(files) {
+		current, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatalf("error while reading %s: %s", name, err)
+		}
+		if !bytes.Equal(current, files[name]) {
+			t.Errorf("expected %s to be up to date, run go generate ./hooks", name)
+		}
+	}
+}
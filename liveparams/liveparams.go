@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"sync"
 
 	gql "github.com/graphql-go/graphql"
 	"github.com/heroiclabs/nakama/runtime"
 
+	"github.com/mastern2k3/poseidon/audit"
 	"github.com/mastern2k3/poseidon/graphql"
 	"github.com/mastern2k3/poseidon/rpc"
 	"github.com/mastern2k3/poseidon/storage"
@@ -32,6 +34,15 @@ var (
 	}
 
 	liveParameters = map[string]interface{}{}
+
+	// watchers are the streams of the subscribers to changes, with the names each one is limited to
+	watchersMutex sync.Mutex
+	watchers      = map[chan interface{}]map[string]bool{}
+)
+
+const (
+	// watcherBuffer is the number of changes kept for a slow subscriber, later changes are dropped until it catches up
+	watcherBuffer = 64
 )
 
 var (
@@ -85,6 +96,31 @@ var (
 			return name, SetLiveParamString(p.Context, nk, name, newValue)
 		},
 	}
+
+	liveParamChangedSubscription = &graphql.Subscription{
+		Field: &gql.Field{
+			Description: "Sends live parameters as they are set, through GraphQL or the `liveparams_set` RPC.",
+			Args: gql.FieldConfigArgument{
+				"names": &gql.ArgumentConfig{
+					Type:        gql.NewList(gql.NewNonNull(gql.String)),
+					Description: "Only send changes to these parameters, every change is sent when omitted.",
+				},
+			},
+			Type: gql.NewNonNull(liveParamType),
+		},
+		Subscribe: func(p gql.ResolveParams) (<-chan interface{}, error) {
+			names := map[string]bool{}
+			if list, ok := p.Args["names"].([]interface{}); ok {
+				for _, name := range list {
+					if _, has := liveParameters[name.(string)]; !has {
+						return nil, fmt.Errorf("cannot find a live parameter with name `%s`", name)
+					}
+					names[name.(string)] = true
+				}
+			}
+			return watch(p.Context, names), nil
+		},
+	}
 )
 
 type LiveParamsModel struct {
@@ -181,19 +217,14 @@ func RegisterLiveParameters(ctx context.Context, nk runtime.NakamaModule, init r
 	if err := rpc.RegisterRoutes(init, liveParametersRoutes); err != nil {
 		return err
 	}
-	if err := graphql.ConfigureRootQuery(func(rootQuery *gql.Object) error {
-		rootQuery.AddFieldConfig("liveParams", liveParamsField)
-		return nil
-	}); err != nil {
-		return err
-	}
-	if err := graphql.ConfigureRootMutation(func(rootMutation *gql.Object) error {
-		rootMutation.AddFieldConfig("setLiveParam", setliveParamField)
-		return nil
-	}); err != nil {
-		return err
-	}
-	return nil
+	return graphql.Extend(graphql.Extension{
+		Module:    "liveparams",
+		Queries:   gql.Fields{"liveParams": liveParamsField},
+		Mutations: gql.Fields{"setLiveParam": setliveParamField},
+		Subscriptions: graphql.Subscriptions{
+			"liveParamChanged": liveParamChangedSubscription,
+		},
+	})
 }
 
 func GetLiveParamString(name string) (string, error) {
@@ -216,10 +247,11 @@ func GetLiveParamString(name string) (string, error) {
 }
 
 func SetLiveParamString(ctx context.Context, nk runtime.NakamaModule, name string, newValue string) error {
-	liveParam, has := liveParameters[name]
-	if !has {
-		return fmt.Errorf("cannot find a live parameter with name `%s`", name)
+	before, err := GetLiveParamString(name)
+	if err != nil {
+		return err
 	}
+	liveParam := liveParameters[name]
 	switch v := liveParam.(type) {
 	case *int:
 		newInt, err := strconv.Atoi(newValue)
@@ -244,9 +276,59 @@ func SetLiveParamString(ctx context.Context, nk runtime.NakamaModule, name strin
 	default:
 		return fmt.Errorf("cannot set live param of type `%T`", v)
 	}
-	return liveParametersAccessor.Save(ctx, nk, "", &LiveParamsModel{
+	after, err := GetLiveParamString(name)
+	if err != nil {
+		return err
+	}
+	write, err := liveParametersAccessor.NewWrite("", &LiveParamsModel{
 		Parameters: liveParameters,
 	})
+	if err != nil {
+		return err
+	}
+	// The parameters are saved along with their audit entry, so that neither is written without the other
+	entry, err := audit.NewWrite(ctx, "setLiveParam", "liveparam:"+name, before, after)
+	if err != nil {
+		return err
+	}
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{write, entry}); err != nil {
+		return err
+	}
+	notifyChanged(name)
+	return nil
+}
+
+// watch returns a stream of the names of the parameters set until ctx is done, limited to names unless it is empty
+func watch(ctx context.Context, names map[string]bool) <-chan interface{} {
+	changes := make(chan interface{}, watcherBuffer)
+
+	watchersMutex.Lock()
+	watchers[changes] = names
+	watchersMutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		watchersMutex.Lock()
+		defer watchersMutex.Unlock()
+		delete(watchers, changes)
+		close(changes)
+	}()
+
+	return changes
+}
+
+func notifyChanged(name string) {
+	watchersMutex.Lock()
+	defer watchersMutex.Unlock()
+	for changes, names := range watchers {
+		if len(names) > 0 && !names[name] {
+			continue
+		}
+		select {
+		case changes <- name:
+		default:
+		}
+	}
 }
 
 type SetLiveParam_Request struct {
@@ -256,7 +338,7 @@ type SetLiveParam_Request struct {
 
 func setLiveParam(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
 	req := input.(*SetLiveParam_Request)
-	return nil, SetLiveParamString(ctx, nk, req.Name, req.NewValue)
+	return nil, SetLiveParamString(audit.EnsureRequestID(ctx), nk, req.Name, req.NewValue)
 }
 
 func GetAll(ctx context.Context, nk runtime.NakamaModule) (map[string]interface{}, error) {
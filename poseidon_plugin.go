@@ -6,6 +6,7 @@ import (
 
 	"github.com/heroiclabs/nakama/runtime"
 
+	"github.com/mastern2k3/poseidon/audit"
 	"github.com/mastern2k3/poseidon/graphql"
 	"github.com/mastern2k3/poseidon/ui"
 )
@@ -14,7 +15,10 @@ func InitModule(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runti
 	if err := graphql.RegisterGraphQL(initializer); err != nil {
 		return err
 	}
-	if err := ui.RegisterUI(initializer); err != nil {
+	if err := audit.RegisterAudit(ctx, logger, db, initializer, audit.Options{}); err != nil {
+		return err
+	}
+	if err := ui.RegisterUI(ctx, logger, db, nk, initializer, ui.Options{}); err != nil {
 		return err
 	}
 	return nil
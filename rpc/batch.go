@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+const (
+	BatchRouteName = "rpc_batch"
+)
+
+var (
+	// MaxBatchSize limits the number of calls a single batch may contain
+	MaxBatchSize = 32
+
+	registeredHandlersMutex sync.RWMutex
+	// registeredHandlers are keyed by their lower cased names, as Nakama lower cases the ids of RPCs
+	registeredHandlers = map[string]rpcHandler{}
+)
+
+// BatchRoutes dispatches several calls in a single RPC to the routes named allowed, which must be registered with
+// RegisterRoutes, any other route being refused
+func BatchRoutes(allowed ...string) []RPCRoute {
+	names := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		names[strings.ToLower(name)] = true
+	}
+	return []RPCRoute{
+		&JsonRoute{Name: BatchRouteName, InputModel: func() interface{} { return new(BatchRequest) }, Handler: batchHandler(names)},
+	}
+}
+
+type rpcHandler = func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error)
+
+type BatchRequest struct {
+	Calls []BatchCall `json:"calls"`
+	// Parallel dispatches all calls concurrently instead of one after the other
+	Parallel bool `json:"parallel"`
+}
+
+type BatchCall struct {
+	Name    string `json:"name"`
+	Payload string `json:"payload"`
+}
+
+type BatchResult struct {
+	Name    string `json:"name"`
+	Payload string `json:"payload,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type recordingInitializer struct {
+	runtime.Initializer
+}
+
+func (i *recordingInitializer) RegisterRpc(id string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error)) error {
+	if err := i.Initializer.RegisterRpc(id, fn); err != nil {
+		return err
+	}
+	registeredHandlersMutex.Lock()
+	registeredHandlers[strings.ToLower(id)] = fn
+	registeredHandlersMutex.Unlock()
+	return nil
+}
+
+func lookupHandler(allowed map[string]bool, name string) (rpcHandler, error) {
+	name = strings.ToLower(name)
+	if name == BatchRouteName {
+		return nil, fmt.Errorf("`%s` cannot be called from within a batch", BatchRouteName)
+	}
+	if !allowed[name] {
+		return nil, fmt.Errorf("route `%s` cannot be called from within a batch", name)
+	}
+	registeredHandlersMutex.RLock()
+	defer registeredHandlersMutex.RUnlock()
+	handler, has := registeredHandlers[name]
+	if !has {
+		return nil, fmt.Errorf("no route registered with name `%s`", name)
+	}
+	return handler, nil
+}
+
+func batchHandler(allowed map[string]bool) func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
+
+		req := input.(*BatchRequest)
+
+		if len(req.Calls) > MaxBatchSize {
+			return nil, fmt.Errorf("batch of %d calls exceeds the maximum of %d", len(req.Calls), MaxBatchSize)
+		}
+
+		results := make([]BatchResult, len(req.Calls))
+
+		call := func(i int) {
+			results[i].Name = req.Calls[i].Name
+
+			// A panicking call fails on its own rather than taking down the batch, or the server when in parallel
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Error("call to `%s` within a batch panicked: %v", req.Calls[i].Name, r)
+					results[i].Payload = ""
+					results[i].Error = fmt.Sprintf("call to `%s` panicked", req.Calls[i].Name)
+				}
+			}()
+
+			handler, err := lookupHandler(allowed, req.Calls[i].Name)
+			if err == nil {
+				results[i].Payload, err = handler(ctx, logger, db, nk, req.Calls[i].Payload)
+			}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}
+
+		if !req.Parallel {
+			for i := range req.Calls {
+				call(i)
+			}
+			return results, nil
+		}
+
+		var wg sync.WaitGroup
+
+		for i := range req.Calls {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				call(i)
+			}(i)
+		}
+
+		wg.Wait()
+
+		return results, nil
+	}
+}
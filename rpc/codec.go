@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+// Codec converts between RPC payloads and the models handled by a CodecRoute
+type Codec interface {
+	Unmarshal(payload string, model interface{}) error
+	Marshal(model interface{}) (string, error)
+}
+
+var (
+	// JSON encodes models as json, as JsonRoute does
+	JSON Codec = jsonCodec{}
+
+	// Protobuf encodes generated protobuf messages as raw binary payloads
+	Protobuf Codec = protoCodec{base64: false}
+
+	// Base64Protobuf encodes generated protobuf messages as base64 text, safe for clients that can only send strings
+	Base64Protobuf Codec = protoCodec{base64: true}
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) Unmarshal(payload string, model interface{}) error {
+	return json.Unmarshal([]byte(payload), model)
+}
+
+func (jsonCodec) Marshal(model interface{}) (string, error) {
+	bytes, err := json.Marshal(model)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+type protoCodec struct {
+	base64 bool
+}
+
+func (c protoCodec) Unmarshal(payload string, model interface{}) error {
+	message, ok := model.(proto.Message)
+	if !ok {
+		return fmt.Errorf("model typed `%T` is not a protobuf message", model)
+	}
+	bytes := []byte(payload)
+	if c.base64 {
+		var err error
+		if bytes, err = base64.StdEncoding.DecodeString(payload); err != nil {
+			return err
+		}
+	}
+	return proto.Unmarshal(bytes, message)
+}
+
+func (c protoCodec) Marshal(model interface{}) (string, error) {
+	if model == nil {
+		return "", nil
+	}
+	message, ok := model.(proto.Message)
+	if !ok {
+		return "", fmt.Errorf("model typed `%T` is not a protobuf message", model)
+	}
+	bytes, err := proto.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	if c.base64 {
+		return base64.StdEncoding.EncodeToString(bytes), nil
+	}
+	return string(bytes), nil
+}
+
+// CodecRoute is a route whose input and output models are converted using Codec,
+// InputModel should return a pointer to an empty model, such as a generated protobuf message
+type CodecRoute struct {
+	Name       string
+	Codec      Codec
+	InputModel func() interface{}
+	Handler    func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error)
+}
+
+func (h *CodecRoute) Register(init runtime.Initializer) error {
+	return init.RegisterRpc(
+		h.Name,
+		func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+
+			var inputModel interface{}
+
+			if h.InputModel != nil {
+				inputModel = h.InputModel()
+
+				if err := h.Codec.Unmarshal(payload, inputModel); err != nil {
+					return "", err
+				}
+			}
+
+			outputModel, err := h.Handler(ctx, logger, db, nk, inputModel)
+
+			if err != nil {
+				logger.Error("error while handling `%s`: %s", h.Name, err)
+				return "", err
+			}
+
+			return h.Codec.Marshal(outputModel)
+		},
+	)
+}
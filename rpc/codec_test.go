@@ -0,0 +1,101 @@
+package rpc_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc"
+	"github.com/mastern2k3/poseidon/rpc/rpctest"
+)
+
+func TestCodecs(t *testing.T) {
+
+	for name, codec := range map[string]rpc.Codec{"Protobuf": rpc.Protobuf, "Base64Protobuf": rpc.Base64Protobuf} {
+
+		payload, err := codec.Marshal(&api.ReadStorageObjectId{Collection: "items", Key: "sword", UserId: "some-user"})
+		if err != nil {
+			t.Fatalf("%s: error while marshalling: %s", name, err)
+		}
+
+		var id api.ReadStorageObjectId
+		if err := codec.Unmarshal(payload, &id); err != nil {
+			t.Fatalf("%s: error while unmarshalling: %s", name, err)
+		}
+		if id.Collection != "items" || id.Key != "sword" || id.UserId != "some-user" {
+			t.Fatalf("%s: expected the message to round-trip but got %+v", name, id)
+		}
+
+		if _, err := codec.Marshal(&echoRequest{"hi"}); err == nil {
+			t.Fatalf("%s: expected a model that is not a protobuf message to be refused", name)
+		}
+		if err := codec.Unmarshal(payload, &echoRequest{}); err == nil {
+			t.Fatalf("%s: expected a model that is not a protobuf message to be refused", name)
+		}
+		if payload, err := codec.Marshal(nil); err != nil || payload != "" {
+			t.Fatalf("%s: expected no output to marshal to an empty payload but got %q, %v", name, payload, err)
+		}
+	}
+
+	if err := rpc.Protobuf.Unmarshal("\xff\xff", &api.ReadStorageObjectId{}); err == nil {
+		t.Fatalf("expected a malformed protobuf payload to be refused")
+	}
+	if err := rpc.Base64Protobuf.Unmarshal("not base64!", &api.ReadStorageObjectId{}); err == nil {
+		t.Fatalf("expected a payload that is not base64 to be refused")
+	}
+	if err := rpc.Base64Protobuf.Unmarshal(base64.StdEncoding.EncodeToString([]byte("\xff\xff")), &api.ReadStorageObjectId{}); err == nil {
+		t.Fatalf("expected a malformed protobuf payload to be refused once decoded")
+	}
+
+	payload, err := rpc.JSON.Marshal(&echoRequest{"hi"})
+	if err != nil || payload != `{"text":"hi"}` {
+		t.Fatalf("expected the json payload of the model but got %q, %v", payload, err)
+	}
+	var req echoRequest
+	if err := rpc.JSON.Unmarshal(payload, &req); err != nil || req.Text != "hi" {
+		t.Fatalf("expected the model to round-trip but got %+v, %v", req, err)
+	}
+	if err := rpc.JSON.Unmarshal("{", &req); err == nil {
+		t.Fatalf("expected a malformed json payload to be refused")
+	}
+}
+
+func TestCodecRoute(t *testing.T) {
+
+	route := &rpc.CodecRoute{
+		Name:       "storage_key",
+		Codec:      rpc.Base64Protobuf,
+		InputModel: func() interface{} { return new(api.ReadStorageObjectId) },
+		Handler: func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
+			id := input.(*api.ReadStorageObjectId)
+			return &api.ReadStorageObjectId{Collection: id.Collection, Key: id.Key + "_copy"}, nil
+		},
+	}
+	init := rpctest.New(t, nil, route)
+
+	payload, err := rpc.Base64Protobuf.Marshal(&api.ReadStorageObjectId{Collection: "items", Key: "sword"})
+	if err != nil {
+		t.Fatalf("error while marshalling: %s", err)
+	}
+
+	response, err := init.Call(context.Background(), "storage_key", payload)
+	if err != nil {
+		t.Fatalf("error while calling storage_key: %s", err)
+	}
+	var id api.ReadStorageObjectId
+	if err := rpc.Base64Protobuf.Unmarshal(response, &id); err != nil {
+		t.Fatalf("error while unmarshalling the response: %s", err)
+	}
+	if !proto.Equal(&id, &api.ReadStorageObjectId{Collection: "items", Key: "sword_copy"}) {
+		t.Fatalf("unexpected response %+v", id)
+	}
+
+	if _, err := init.Call(context.Background(), "storage_key", "not base64!"); err == nil {
+		t.Fatalf("expected a payload that does not decode to be refused")
+	}
+}
@@ -0,0 +1,1108 @@
+// Code generated by genhooks from the runtime.Initializer of Nakama. DO NOT EDIT.
+
+package rpc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/rtapi"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+func (i *middlewareInitializer) RegisterBeforeRt(id string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) (*rtapi.Envelope, error)) error {
+	return i.Initializer.RegisterBeforeRt(id, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) (*rtapi.Envelope, error) {
+		var result *rtapi.Envelope
+		err := i.middleware(ctx, logger, "BeforeRt"+id, func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, envelope)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterRt(id string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) error) error {
+	return i.Initializer.RegisterAfterRt(id, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) error {
+		return i.middleware(ctx, logger, "AfterRt"+id, func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, envelope)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeGetAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error) error {
+	return i.Initializer.RegisterBeforeGetAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error {
+		return i.middleware(ctx, logger, "BeforeGetAccount", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterGetAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Account) error) error {
+	return i.Initializer.RegisterAfterGetAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Account) error {
+		return i.middleware(ctx, logger, "AfterGetAccount", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeUpdateAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) (*api.UpdateAccountRequest, error)) error {
+	return i.Initializer.RegisterBeforeUpdateAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) (*api.UpdateAccountRequest, error) {
+		var result *api.UpdateAccountRequest
+		err := i.middleware(ctx, logger, "BeforeUpdateAccount", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterUpdateAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) error) error {
+	return i.Initializer.RegisterAfterUpdateAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) error {
+		return i.middleware(ctx, logger, "AfterUpdateAccount", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeAuthenticateCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateCustomRequest) (*api.AuthenticateCustomRequest, error)) error {
+	return i.Initializer.RegisterBeforeAuthenticateCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateCustomRequest) (*api.AuthenticateCustomRequest, error) {
+		var result *api.AuthenticateCustomRequest
+		err := i.middleware(ctx, logger, "BeforeAuthenticateCustom", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterAuthenticateCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateCustomRequest) error) error {
+	return i.Initializer.RegisterAfterAuthenticateCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateCustomRequest) error {
+		return i.middleware(ctx, logger, "AfterAuthenticateCustom", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeAuthenticateDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateDeviceRequest) (*api.AuthenticateDeviceRequest, error)) error {
+	return i.Initializer.RegisterBeforeAuthenticateDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateDeviceRequest) (*api.AuthenticateDeviceRequest, error) {
+		var result *api.AuthenticateDeviceRequest
+		err := i.middleware(ctx, logger, "BeforeAuthenticateDevice", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterAuthenticateDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateDeviceRequest) error) error {
+	return i.Initializer.RegisterAfterAuthenticateDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateDeviceRequest) error {
+		return i.middleware(ctx, logger, "AfterAuthenticateDevice", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeAuthenticateEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateEmailRequest) (*api.AuthenticateEmailRequest, error)) error {
+	return i.Initializer.RegisterBeforeAuthenticateEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateEmailRequest) (*api.AuthenticateEmailRequest, error) {
+		var result *api.AuthenticateEmailRequest
+		err := i.middleware(ctx, logger, "BeforeAuthenticateEmail", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterAuthenticateEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateEmailRequest) error) error {
+	return i.Initializer.RegisterAfterAuthenticateEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateEmailRequest) error {
+		return i.middleware(ctx, logger, "AfterAuthenticateEmail", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeAuthenticateFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateFacebookRequest) (*api.AuthenticateFacebookRequest, error)) error {
+	return i.Initializer.RegisterBeforeAuthenticateFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateFacebookRequest) (*api.AuthenticateFacebookRequest, error) {
+		var result *api.AuthenticateFacebookRequest
+		err := i.middleware(ctx, logger, "BeforeAuthenticateFacebook", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterAuthenticateFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateFacebookRequest) error) error {
+	return i.Initializer.RegisterAfterAuthenticateFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateFacebookRequest) error {
+		return i.middleware(ctx, logger, "AfterAuthenticateFacebook", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeAuthenticateGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGameCenterRequest) (*api.AuthenticateGameCenterRequest, error)) error {
+	return i.Initializer.RegisterBeforeAuthenticateGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGameCenterRequest) (*api.AuthenticateGameCenterRequest, error) {
+		var result *api.AuthenticateGameCenterRequest
+		err := i.middleware(ctx, logger, "BeforeAuthenticateGameCenter", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterAuthenticateGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGameCenterRequest) error) error {
+	return i.Initializer.RegisterAfterAuthenticateGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGameCenterRequest) error {
+		return i.middleware(ctx, logger, "AfterAuthenticateGameCenter", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeAuthenticateGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGoogleRequest) (*api.AuthenticateGoogleRequest, error)) error {
+	return i.Initializer.RegisterBeforeAuthenticateGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGoogleRequest) (*api.AuthenticateGoogleRequest, error) {
+		var result *api.AuthenticateGoogleRequest
+		err := i.middleware(ctx, logger, "BeforeAuthenticateGoogle", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterAuthenticateGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGoogleRequest) error) error {
+	return i.Initializer.RegisterAfterAuthenticateGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGoogleRequest) error {
+		return i.middleware(ctx, logger, "AfterAuthenticateGoogle", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeAuthenticateSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateSteamRequest) (*api.AuthenticateSteamRequest, error)) error {
+	return i.Initializer.RegisterBeforeAuthenticateSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateSteamRequest) (*api.AuthenticateSteamRequest, error) {
+		var result *api.AuthenticateSteamRequest
+		err := i.middleware(ctx, logger, "BeforeAuthenticateSteam", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterAuthenticateSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateSteamRequest) error) error {
+	return i.Initializer.RegisterAfterAuthenticateSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateSteamRequest) error {
+		return i.middleware(ctx, logger, "AfterAuthenticateSteam", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListChannelMessages(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListChannelMessagesRequest) (*api.ListChannelMessagesRequest, error)) error {
+	return i.Initializer.RegisterBeforeListChannelMessages(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListChannelMessagesRequest) (*api.ListChannelMessagesRequest, error) {
+		var result *api.ListChannelMessagesRequest
+		err := i.middleware(ctx, logger, "BeforeListChannelMessages", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListChannelMessages(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ChannelMessageList, in *api.ListChannelMessagesRequest) error) error {
+	return i.Initializer.RegisterAfterListChannelMessages(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ChannelMessageList, in *api.ListChannelMessagesRequest) error {
+		return i.middleware(ctx, logger, "AfterListChannelMessages", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error) error {
+	return i.Initializer.RegisterBeforeListFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error {
+		return i.middleware(ctx, logger, "BeforeListFriends", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Friends) error) error {
+	return i.Initializer.RegisterAfterListFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Friends) error {
+		return i.middleware(ctx, logger, "AfterListFriends", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeAddFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) (*api.AddFriendsRequest, error)) error {
+	return i.Initializer.RegisterBeforeAddFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) (*api.AddFriendsRequest, error) {
+		var result *api.AddFriendsRequest
+		err := i.middleware(ctx, logger, "BeforeAddFriends", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterAddFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) error) error {
+	return i.Initializer.RegisterAfterAddFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) error {
+		return i.middleware(ctx, logger, "AfterAddFriends", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeDeleteFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) (*api.DeleteFriendsRequest, error)) error {
+	return i.Initializer.RegisterBeforeDeleteFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) (*api.DeleteFriendsRequest, error) {
+		var result *api.DeleteFriendsRequest
+		err := i.middleware(ctx, logger, "BeforeDeleteFriends", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterDeleteFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) error) error {
+	return i.Initializer.RegisterAfterDeleteFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) error {
+		return i.middleware(ctx, logger, "AfterDeleteFriends", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeBlockFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) (*api.BlockFriendsRequest, error)) error {
+	return i.Initializer.RegisterBeforeBlockFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) (*api.BlockFriendsRequest, error) {
+		var result *api.BlockFriendsRequest
+		err := i.middleware(ctx, logger, "BeforeBlockFriends", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterBlockFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) error) error {
+	return i.Initializer.RegisterAfterBlockFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) error {
+		return i.middleware(ctx, logger, "AfterBlockFriends", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeImportFacebookFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) (*api.ImportFacebookFriendsRequest, error)) error {
+	return i.Initializer.RegisterBeforeImportFacebookFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) (*api.ImportFacebookFriendsRequest, error) {
+		var result *api.ImportFacebookFriendsRequest
+		err := i.middleware(ctx, logger, "BeforeImportFacebookFriends", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterImportFacebookFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) error) error {
+	return i.Initializer.RegisterAfterImportFacebookFriends(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) error {
+		return i.middleware(ctx, logger, "AfterImportFacebookFriends", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeCreateGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.CreateGroupRequest) (*api.CreateGroupRequest, error)) error {
+	return i.Initializer.RegisterBeforeCreateGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.CreateGroupRequest) (*api.CreateGroupRequest, error) {
+		var result *api.CreateGroupRequest
+		err := i.middleware(ctx, logger, "BeforeCreateGroup", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterCreateGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Group, in *api.CreateGroupRequest) error) error {
+	return i.Initializer.RegisterAfterCreateGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Group, in *api.CreateGroupRequest) error {
+		return i.middleware(ctx, logger, "AfterCreateGroup", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeUpdateGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) (*api.UpdateGroupRequest, error)) error {
+	return i.Initializer.RegisterBeforeUpdateGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) (*api.UpdateGroupRequest, error) {
+		var result *api.UpdateGroupRequest
+		err := i.middleware(ctx, logger, "BeforeUpdateGroup", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterUpdateGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) error) error {
+	return i.Initializer.RegisterAfterUpdateGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) error {
+		return i.middleware(ctx, logger, "AfterUpdateGroup", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeDeleteGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) (*api.DeleteGroupRequest, error)) error {
+	return i.Initializer.RegisterBeforeDeleteGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) (*api.DeleteGroupRequest, error) {
+		var result *api.DeleteGroupRequest
+		err := i.middleware(ctx, logger, "BeforeDeleteGroup", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterDeleteGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) error) error {
+	return i.Initializer.RegisterAfterDeleteGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) error {
+		return i.middleware(ctx, logger, "AfterDeleteGroup", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeJoinGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) (*api.JoinGroupRequest, error)) error {
+	return i.Initializer.RegisterBeforeJoinGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) (*api.JoinGroupRequest, error) {
+		var result *api.JoinGroupRequest
+		err := i.middleware(ctx, logger, "BeforeJoinGroup", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterJoinGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) error) error {
+	return i.Initializer.RegisterAfterJoinGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) error {
+		return i.middleware(ctx, logger, "AfterJoinGroup", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeLeaveGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) (*api.LeaveGroupRequest, error)) error {
+	return i.Initializer.RegisterBeforeLeaveGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) (*api.LeaveGroupRequest, error) {
+		var result *api.LeaveGroupRequest
+		err := i.middleware(ctx, logger, "BeforeLeaveGroup", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterLeaveGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) error) error {
+	return i.Initializer.RegisterAfterLeaveGroup(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) error {
+		return i.middleware(ctx, logger, "AfterLeaveGroup", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeAddGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) (*api.AddGroupUsersRequest, error)) error {
+	return i.Initializer.RegisterBeforeAddGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) (*api.AddGroupUsersRequest, error) {
+		var result *api.AddGroupUsersRequest
+		err := i.middleware(ctx, logger, "BeforeAddGroupUsers", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterAddGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) error) error {
+	return i.Initializer.RegisterAfterAddGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) error {
+		return i.middleware(ctx, logger, "AfterAddGroupUsers", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeKickGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) (*api.KickGroupUsersRequest, error)) error {
+	return i.Initializer.RegisterBeforeKickGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) (*api.KickGroupUsersRequest, error) {
+		var result *api.KickGroupUsersRequest
+		err := i.middleware(ctx, logger, "BeforeKickGroupUsers", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterKickGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) error) error {
+	return i.Initializer.RegisterAfterKickGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) error {
+		return i.middleware(ctx, logger, "AfterKickGroupUsers", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforePromoteGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) (*api.PromoteGroupUsersRequest, error)) error {
+	return i.Initializer.RegisterBeforePromoteGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) (*api.PromoteGroupUsersRequest, error) {
+		var result *api.PromoteGroupUsersRequest
+		err := i.middleware(ctx, logger, "BeforePromoteGroupUsers", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterPromoteGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) error) error {
+	return i.Initializer.RegisterAfterPromoteGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) error {
+		return i.middleware(ctx, logger, "AfterPromoteGroupUsers", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupUsersRequest) (*api.ListGroupUsersRequest, error)) error {
+	return i.Initializer.RegisterBeforeListGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupUsersRequest) (*api.ListGroupUsersRequest, error) {
+		var result *api.ListGroupUsersRequest
+		err := i.middleware(ctx, logger, "BeforeListGroupUsers", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupUserList, in *api.ListGroupUsersRequest) error) error {
+	return i.Initializer.RegisterAfterListGroupUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupUserList, in *api.ListGroupUsersRequest) error {
+		return i.middleware(ctx, logger, "AfterListGroupUsers", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListUserGroups(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListUserGroupsRequest) (*api.ListUserGroupsRequest, error)) error {
+	return i.Initializer.RegisterBeforeListUserGroups(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListUserGroupsRequest) (*api.ListUserGroupsRequest, error) {
+		var result *api.ListUserGroupsRequest
+		err := i.middleware(ctx, logger, "BeforeListUserGroups", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListUserGroups(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.UserGroupList, in *api.ListUserGroupsRequest) error) error {
+	return i.Initializer.RegisterAfterListUserGroups(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.UserGroupList, in *api.ListUserGroupsRequest) error {
+		return i.middleware(ctx, logger, "AfterListUserGroups", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListGroups(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupsRequest) (*api.ListGroupsRequest, error)) error {
+	return i.Initializer.RegisterBeforeListGroups(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupsRequest) (*api.ListGroupsRequest, error) {
+		var result *api.ListGroupsRequest
+		err := i.middleware(ctx, logger, "BeforeListGroups", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListGroups(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupList, in *api.ListGroupsRequest) error) error {
+	return i.Initializer.RegisterAfterListGroups(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupList, in *api.ListGroupsRequest) error {
+		return i.middleware(ctx, logger, "AfterListGroups", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeDeleteLeaderboardRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) (*api.DeleteLeaderboardRecordRequest, error)) error {
+	return i.Initializer.RegisterBeforeDeleteLeaderboardRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) (*api.DeleteLeaderboardRecordRequest, error) {
+		var result *api.DeleteLeaderboardRecordRequest
+		err := i.middleware(ctx, logger, "BeforeDeleteLeaderboardRecord", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterDeleteLeaderboardRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) error) error {
+	return i.Initializer.RegisterAfterDeleteLeaderboardRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) error {
+		return i.middleware(ctx, logger, "AfterDeleteLeaderboardRecord", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListLeaderboardRecords(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsRequest) (*api.ListLeaderboardRecordsRequest, error)) error {
+	return i.Initializer.RegisterBeforeListLeaderboardRecords(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsRequest) (*api.ListLeaderboardRecordsRequest, error) {
+		var result *api.ListLeaderboardRecordsRequest
+		err := i.middleware(ctx, logger, "BeforeListLeaderboardRecords", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListLeaderboardRecords(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsRequest) error) error {
+	return i.Initializer.RegisterAfterListLeaderboardRecords(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsRequest) error {
+		return i.middleware(ctx, logger, "AfterListLeaderboardRecords", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeWriteLeaderboardRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteLeaderboardRecordRequest) (*api.WriteLeaderboardRecordRequest, error)) error {
+	return i.Initializer.RegisterBeforeWriteLeaderboardRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteLeaderboardRecordRequest) (*api.WriteLeaderboardRecordRequest, error) {
+		var result *api.WriteLeaderboardRecordRequest
+		err := i.middleware(ctx, logger, "BeforeWriteLeaderboardRecord", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterWriteLeaderboardRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteLeaderboardRecordRequest) error) error {
+	return i.Initializer.RegisterAfterWriteLeaderboardRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteLeaderboardRecordRequest) error {
+		return i.middleware(ctx, logger, "AfterWriteLeaderboardRecord", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListLeaderboardRecordsAroundOwner(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsAroundOwnerRequest) (*api.ListLeaderboardRecordsAroundOwnerRequest, error)) error {
+	return i.Initializer.RegisterBeforeListLeaderboardRecordsAroundOwner(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsAroundOwnerRequest) (*api.ListLeaderboardRecordsAroundOwnerRequest, error) {
+		var result *api.ListLeaderboardRecordsAroundOwnerRequest
+		err := i.middleware(ctx, logger, "BeforeListLeaderboardRecordsAroundOwner", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListLeaderboardRecordsAroundOwner(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsAroundOwnerRequest) error) error {
+	return i.Initializer.RegisterAfterListLeaderboardRecordsAroundOwner(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsAroundOwnerRequest) error {
+		return i.middleware(ctx, logger, "AfterListLeaderboardRecordsAroundOwner", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeLinkCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error)) error {
+	return i.Initializer.RegisterBeforeLinkCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error) {
+		var result *api.AccountCustom
+		err := i.middleware(ctx, logger, "BeforeLinkCustom", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterLinkCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error) error {
+	return i.Initializer.RegisterAfterLinkCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error {
+		return i.middleware(ctx, logger, "AfterLinkCustom", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeLinkDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error)) error {
+	return i.Initializer.RegisterBeforeLinkDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error) {
+		var result *api.AccountDevice
+		err := i.middleware(ctx, logger, "BeforeLinkDevice", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterLinkDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error) error {
+	return i.Initializer.RegisterAfterLinkDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error {
+		return i.middleware(ctx, logger, "AfterLinkDevice", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeLinkEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error)) error {
+	return i.Initializer.RegisterBeforeLinkEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error) {
+		var result *api.AccountEmail
+		err := i.middleware(ctx, logger, "BeforeLinkEmail", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterLinkEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error) error {
+	return i.Initializer.RegisterAfterLinkEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error {
+		return i.middleware(ctx, logger, "AfterLinkEmail", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeLinkFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) (*api.LinkFacebookRequest, error)) error {
+	return i.Initializer.RegisterBeforeLinkFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) (*api.LinkFacebookRequest, error) {
+		var result *api.LinkFacebookRequest
+		err := i.middleware(ctx, logger, "BeforeLinkFacebook", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterLinkFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) error) error {
+	return i.Initializer.RegisterAfterLinkFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) error {
+		return i.middleware(ctx, logger, "AfterLinkFacebook", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeLinkGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error)) error {
+	return i.Initializer.RegisterBeforeLinkGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error) {
+		var result *api.AccountGameCenter
+		err := i.middleware(ctx, logger, "BeforeLinkGameCenter", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterLinkGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error) error {
+	return i.Initializer.RegisterAfterLinkGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error {
+		return i.middleware(ctx, logger, "AfterLinkGameCenter", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeLinkGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error)) error {
+	return i.Initializer.RegisterBeforeLinkGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error) {
+		var result *api.AccountGoogle
+		err := i.middleware(ctx, logger, "BeforeLinkGoogle", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterLinkGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error) error {
+	return i.Initializer.RegisterAfterLinkGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error {
+		return i.middleware(ctx, logger, "AfterLinkGoogle", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeLinkSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) (*api.AccountSteam, error)) error {
+	return i.Initializer.RegisterBeforeLinkSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) (*api.AccountSteam, error) {
+		var result *api.AccountSteam
+		err := i.middleware(ctx, logger, "BeforeLinkSteam", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterLinkSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) error) error {
+	return i.Initializer.RegisterAfterLinkSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) error {
+		return i.middleware(ctx, logger, "AfterLinkSteam", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListMatches(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListMatchesRequest) (*api.ListMatchesRequest, error)) error {
+	return i.Initializer.RegisterBeforeListMatches(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListMatchesRequest) (*api.ListMatchesRequest, error) {
+		var result *api.ListMatchesRequest
+		err := i.middleware(ctx, logger, "BeforeListMatches", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListMatches(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.MatchList, in *api.ListMatchesRequest) error) error {
+	return i.Initializer.RegisterAfterListMatches(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.MatchList, in *api.ListMatchesRequest) error {
+		return i.middleware(ctx, logger, "AfterListMatches", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListNotifications(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListNotificationsRequest) (*api.ListNotificationsRequest, error)) error {
+	return i.Initializer.RegisterBeforeListNotifications(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListNotificationsRequest) (*api.ListNotificationsRequest, error) {
+		var result *api.ListNotificationsRequest
+		err := i.middleware(ctx, logger, "BeforeListNotifications", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListNotifications(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.NotificationList, in *api.ListNotificationsRequest) error) error {
+	return i.Initializer.RegisterAfterListNotifications(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.NotificationList, in *api.ListNotificationsRequest) error {
+		return i.middleware(ctx, logger, "AfterListNotifications", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeDeleteNotification(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) (*api.DeleteNotificationsRequest, error)) error {
+	return i.Initializer.RegisterBeforeDeleteNotification(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) (*api.DeleteNotificationsRequest, error) {
+		var result *api.DeleteNotificationsRequest
+		err := i.middleware(ctx, logger, "BeforeDeleteNotification", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterDeleteNotification(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) error) error {
+	return i.Initializer.RegisterAfterDeleteNotification(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) error {
+		return i.middleware(ctx, logger, "AfterDeleteNotification", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListStorageObjectsRequest) (*api.ListStorageObjectsRequest, error)) error {
+	return i.Initializer.RegisterBeforeListStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListStorageObjectsRequest) (*api.ListStorageObjectsRequest, error) {
+		var result *api.ListStorageObjectsRequest
+		err := i.middleware(ctx, logger, "BeforeListStorageObjects", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectList, in *api.ListStorageObjectsRequest) error) error {
+	return i.Initializer.RegisterAfterListStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectList, in *api.ListStorageObjectsRequest) error {
+		return i.middleware(ctx, logger, "AfterListStorageObjects", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeReadStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ReadStorageObjectsRequest) (*api.ReadStorageObjectsRequest, error)) error {
+	return i.Initializer.RegisterBeforeReadStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ReadStorageObjectsRequest) (*api.ReadStorageObjectsRequest, error) {
+		var result *api.ReadStorageObjectsRequest
+		err := i.middleware(ctx, logger, "BeforeReadStorageObjects", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterReadStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjects, in *api.ReadStorageObjectsRequest) error) error {
+	return i.Initializer.RegisterAfterReadStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjects, in *api.ReadStorageObjectsRequest) error {
+		return i.middleware(ctx, logger, "AfterReadStorageObjects", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeWriteStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteStorageObjectsRequest) (*api.WriteStorageObjectsRequest, error)) error {
+	return i.Initializer.RegisterBeforeWriteStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteStorageObjectsRequest) (*api.WriteStorageObjectsRequest, error) {
+		var result *api.WriteStorageObjectsRequest
+		err := i.middleware(ctx, logger, "BeforeWriteStorageObjects", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterWriteStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectAcks, in *api.WriteStorageObjectsRequest) error) error {
+	return i.Initializer.RegisterAfterWriteStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectAcks, in *api.WriteStorageObjectsRequest) error {
+		return i.middleware(ctx, logger, "AfterWriteStorageObjects", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeDeleteStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) (*api.DeleteStorageObjectsRequest, error)) error {
+	return i.Initializer.RegisterBeforeDeleteStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) (*api.DeleteStorageObjectsRequest, error) {
+		var result *api.DeleteStorageObjectsRequest
+		err := i.middleware(ctx, logger, "BeforeDeleteStorageObjects", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterDeleteStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) error) error {
+	return i.Initializer.RegisterAfterDeleteStorageObjects(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) error {
+		return i.middleware(ctx, logger, "AfterDeleteStorageObjects", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeJoinTournament(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) (*api.JoinTournamentRequest, error)) error {
+	return i.Initializer.RegisterBeforeJoinTournament(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) (*api.JoinTournamentRequest, error) {
+		var result *api.JoinTournamentRequest
+		err := i.middleware(ctx, logger, "BeforeJoinTournament", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterJoinTournament(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) error) error {
+	return i.Initializer.RegisterAfterJoinTournament(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) error {
+		return i.middleware(ctx, logger, "AfterJoinTournament", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListTournamentRecords(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsRequest) (*api.ListTournamentRecordsRequest, error)) error {
+	return i.Initializer.RegisterBeforeListTournamentRecords(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsRequest) (*api.ListTournamentRecordsRequest, error) {
+		var result *api.ListTournamentRecordsRequest
+		err := i.middleware(ctx, logger, "BeforeListTournamentRecords", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListTournamentRecords(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsRequest) error) error {
+	return i.Initializer.RegisterAfterListTournamentRecords(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsRequest) error {
+		return i.middleware(ctx, logger, "AfterListTournamentRecords", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListTournaments(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentsRequest) (*api.ListTournamentsRequest, error)) error {
+	return i.Initializer.RegisterBeforeListTournaments(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentsRequest) (*api.ListTournamentsRequest, error) {
+		var result *api.ListTournamentsRequest
+		err := i.middleware(ctx, logger, "BeforeListTournaments", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListTournaments(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentList, in *api.ListTournamentsRequest) error) error {
+	return i.Initializer.RegisterAfterListTournaments(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentList, in *api.ListTournamentsRequest) error {
+		return i.middleware(ctx, logger, "AfterListTournaments", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeWriteTournamentRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteTournamentRecordRequest) (*api.WriteTournamentRecordRequest, error)) error {
+	return i.Initializer.RegisterBeforeWriteTournamentRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteTournamentRecordRequest) (*api.WriteTournamentRecordRequest, error) {
+		var result *api.WriteTournamentRecordRequest
+		err := i.middleware(ctx, logger, "BeforeWriteTournamentRecord", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterWriteTournamentRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteTournamentRecordRequest) error) error {
+	return i.Initializer.RegisterAfterWriteTournamentRecord(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteTournamentRecordRequest) error {
+		return i.middleware(ctx, logger, "AfterWriteTournamentRecord", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeListTournamentRecordsAroundOwner(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsAroundOwnerRequest) (*api.ListTournamentRecordsAroundOwnerRequest, error)) error {
+	return i.Initializer.RegisterBeforeListTournamentRecordsAroundOwner(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsAroundOwnerRequest) (*api.ListTournamentRecordsAroundOwnerRequest, error) {
+		var result *api.ListTournamentRecordsAroundOwnerRequest
+		err := i.middleware(ctx, logger, "BeforeListTournamentRecordsAroundOwner", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterListTournamentRecordsAroundOwner(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsAroundOwnerRequest) error) error {
+	return i.Initializer.RegisterAfterListTournamentRecordsAroundOwner(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsAroundOwnerRequest) error {
+		return i.middleware(ctx, logger, "AfterListTournamentRecordsAroundOwner", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeUnlinkCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error)) error {
+	return i.Initializer.RegisterBeforeUnlinkCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error) {
+		var result *api.AccountCustom
+		err := i.middleware(ctx, logger, "BeforeUnlinkCustom", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterUnlinkCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error) error {
+	return i.Initializer.RegisterAfterUnlinkCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error {
+		return i.middleware(ctx, logger, "AfterUnlinkCustom", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeUnlinkDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error)) error {
+	return i.Initializer.RegisterBeforeUnlinkDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error) {
+		var result *api.AccountDevice
+		err := i.middleware(ctx, logger, "BeforeUnlinkDevice", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterUnlinkDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error) error {
+	return i.Initializer.RegisterAfterUnlinkDevice(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error {
+		return i.middleware(ctx, logger, "AfterUnlinkDevice", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeUnlinkEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error)) error {
+	return i.Initializer.RegisterBeforeUnlinkEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error) {
+		var result *api.AccountEmail
+		err := i.middleware(ctx, logger, "BeforeUnlinkEmail", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterUnlinkEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error) error {
+	return i.Initializer.RegisterAfterUnlinkEmail(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error {
+		return i.middleware(ctx, logger, "AfterUnlinkEmail", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeUnlinkFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) (*api.AccountFacebook, error)) error {
+	return i.Initializer.RegisterBeforeUnlinkFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) (*api.AccountFacebook, error) {
+		var result *api.AccountFacebook
+		err := i.middleware(ctx, logger, "BeforeUnlinkFacebook", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterUnlinkFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) error) error {
+	return i.Initializer.RegisterAfterUnlinkFacebook(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) error {
+		return i.middleware(ctx, logger, "AfterUnlinkFacebook", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeUnlinkGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error)) error {
+	return i.Initializer.RegisterBeforeUnlinkGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error) {
+		var result *api.AccountGameCenter
+		err := i.middleware(ctx, logger, "BeforeUnlinkGameCenter", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterUnlinkGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error) error {
+	return i.Initializer.RegisterAfterUnlinkGameCenter(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error {
+		return i.middleware(ctx, logger, "AfterUnlinkGameCenter", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeUnlinkGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error)) error {
+	return i.Initializer.RegisterBeforeUnlinkGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error) {
+		var result *api.AccountGoogle
+		err := i.middleware(ctx, logger, "BeforeUnlinkGoogle", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterUnlinkGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error) error {
+	return i.Initializer.RegisterAfterUnlinkGoogle(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error {
+		return i.middleware(ctx, logger, "AfterUnlinkGoogle", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeUnlinkSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) (*api.AccountSteam, error)) error {
+	return i.Initializer.RegisterBeforeUnlinkSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) (*api.AccountSteam, error) {
+		var result *api.AccountSteam
+		err := i.middleware(ctx, logger, "BeforeUnlinkSteam", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterUnlinkSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) error) error {
+	return i.Initializer.RegisterAfterUnlinkSteam(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) error {
+		return i.middleware(ctx, logger, "AfterUnlinkSteam", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, in)
+		})
+	})
+}
+
+func (i *middlewareInitializer) RegisterBeforeGetUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.GetUsersRequest) (*api.GetUsersRequest, error)) error {
+	return i.Initializer.RegisterBeforeGetUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.GetUsersRequest) (*api.GetUsersRequest, error) {
+		var result *api.GetUsersRequest
+		err := i.middleware(ctx, logger, "BeforeGetUsers", func(ctx context.Context) (err error) {
+			result, err = fn(ctx, logger, db, nk, in)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (i *middlewareInitializer) RegisterAfterGetUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Users, in *api.GetUsersRequest) error) error {
+	return i.Initializer.RegisterAfterGetUsers(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Users, in *api.GetUsersRequest) error {
+		return i.middleware(ctx, logger, "AfterGetUsers", func(ctx context.Context) error {
+			return fn(ctx, logger, db, nk, out, in)
+		})
+	})
+}
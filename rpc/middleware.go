@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+type ContextKey string
+
+const (
+	RPC_CTX_PAYLOAD ContextKey = "rpc_payload"
+)
+
+// Middleware wraps the execution of a named handler, calling next continues down the chain
+type Middleware func(ctx context.Context, logger runtime.Logger, name string, next func(ctx context.Context) error) error
+
+// Chain composes several Middleware into one, the first given being the outermost
+func Chain(middleware ...Middleware) Middleware {
+	return func(ctx context.Context, logger runtime.Logger, name string, next func(ctx context.Context) error) error {
+		call := next
+		for i := len(middleware) - 1; i >= 0; i-- {
+			mw, inner := middleware[i], call
+			call = func(ctx context.Context) error {
+				return mw(ctx, logger, name, inner)
+			}
+		}
+		return call(ctx)
+	}
+}
+
+// Invoke runs handler through the given middleware, logging the error it ends with
+func Invoke(ctx context.Context, logger runtime.Logger, mw Middleware, name string, handler func(ctx context.Context) error) error {
+
+	if mw == nil {
+		mw = Chain()
+	}
+
+	err := mw(ctx, logger, name, handler)
+
+	if err != nil {
+		logger.Error("error while handling `%s`: %s", name, err)
+		return err
+	}
+
+	return nil
+}
+
+// ServerOnly is a Middleware refusing calls made on behalf of a user, letting through only the server to server calls
+// Nakama authenticates with its http key
+func ServerOnly(ctx context.Context, logger runtime.Logger, name string, next func(ctx context.Context) error) error {
+	if userID, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string); userID != "" {
+		return fmt.Errorf("`%s` may only be called server to server", name)
+	}
+	return next(ctx)
+}
+
+// WithMiddleware returns routes that register with the given middleware wrapping their handlers, inside of the
+// middleware given to RegisterRoutes
+func WithMiddleware(routes []RPCRoute, middleware ...Middleware) []RPCRoute {
+	wrapped := make([]RPCRoute, len(routes))
+	for i, route := range routes {
+		wrapped[i] = &middlewareRoute{route, Chain(middleware...)}
+	}
+	return wrapped
+}
+
+type middlewareRoute struct {
+	RPCRoute
+	middleware Middleware
+}
+
+func (r *middlewareRoute) Register(init runtime.Initializer) error {
+	return r.RPCRoute.Register(&middlewareInitializer{init, r.middleware})
+}
+
+// Payload returns the raw payload of the RPC the middleware given ctx wraps
+func Payload(ctx context.Context) string {
+	payload, _ := ctx.Value(RPC_CTX_PAYLOAD).(string)
+	return payload
+}
+
+// middlewareInitializer wraps the handlers of the RPCs and hooks registered on it with middleware, the name given to
+// the middleware of a hook being the name of its registration without `Register`, as in `BeforeGetAccount`, the
+// registrations of hooks are generated into hooks.go
+type middlewareInitializer struct {
+	runtime.Initializer
+	middleware Middleware
+}
+
+func (i *middlewareInitializer) RegisterRpc(id string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error)) error {
+	return i.Initializer.RegisterRpc(
+		id,
+		func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+			var output string
+			ctx = context.WithValue(ctx, RPC_CTX_PAYLOAD, payload)
+			err := i.middleware(ctx, logger, id, func(ctx context.Context) error {
+				var err error
+				output, err = fn(ctx, logger, db, nk, payload)
+				return err
+			})
+			return output, err
+		},
+	)
+}
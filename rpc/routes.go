@@ -3,7 +3,6 @@ package rpc
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 
 	"github.com/heroiclabs/nakama/runtime"
 )
@@ -41,39 +40,22 @@ type JsonRoute struct {
 }
 
 func (h *JsonRoute) Register(init runtime.Initializer) error {
-	return init.RegisterRpc(
-		h.Name,
-		func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, inputJson string) (string, error) {
-
-			var inputModel interface{}
-
-			if h.InputModel != nil {
-				inputModel = h.InputModel()
-
-				if err := json.Unmarshal([]byte(inputJson), inputModel); err != nil {
-					return "", err
-				}
-			}
-
-			outputModel, err := h.Handler(ctx, logger, db, nk, inputModel)
-
-			if err != nil {
-				logger.Error("error while handling `%s`: %s", h.Name, err)
-				return "", err
-			}
-
-			bytes, err := json.Marshal(outputModel)
-
-			if err != nil {
-				return "", err
-			}
-
-			return string(bytes), nil
-		},
-	)
+	route := &CodecRoute{
+		Name:       h.Name,
+		Codec:      JSON,
+		InputModel: h.InputModel,
+		Handler:    h.Handler,
+	}
+	return route.Register(init)
 }
 
-func RegisterRoutes(init runtime.Initializer, routes []RPCRoute) error {
+// RegisterRoutes registers each of the routes, wrapping their handlers with the given middleware,
+// registered routes are also made available to the calls of BatchRoutes allowing them
+func RegisterRoutes(init runtime.Initializer, routes []RPCRoute, middleware ...Middleware) error {
+	init = &recordingInitializer{init}
+	if len(middleware) > 0 {
+		init = &middlewareInitializer{init, Chain(middleware...)}
+	}
 	for _, route := range routes {
 		if err := route.Register(init); err != nil {
 			return err
@@ -0,0 +1,283 @@
+package rpc_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/heroiclabs/nakama/rtapi"
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc"
+	"github.com/mastern2k3/poseidon/rpc/rpctest"
+)
+
+type echoRequest struct {
+	Text string `json:"text"`
+}
+
+type echoRequestV1 struct {
+	Message string `json:"message"`
+}
+
+type echoResponse struct {
+	Text   string `json:"text"`
+	UserID string `json:"userId"`
+}
+
+func echo(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
+	req := input.(*echoRequest)
+	if req.Text == "" {
+		return nil, errors.New("nothing to echo")
+	}
+	userID, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+	return &echoResponse{req.Text, userID}, nil
+}
+
+var (
+	testRoutes = []rpc.RPCRoute{
+		&rpc.JsonRoute{Name: "echo", InputModel: func() interface{} { return new(echoRequest) }, Handler: echo},
+		&rpc.VersionedRoute{
+			Name: "echo_versioned",
+			Versions: []rpc.RouteVersion{
+				{
+					Version:    1,
+					InputModel: func() interface{} { return new(echoRequestV1) },
+					Upgrade: func(input interface{}) (interface{}, error) {
+						return &echoRequest{input.(*echoRequestV1).Message}, nil
+					},
+					Downgrade: func(output interface{}) (interface{}, error) {
+						return output.(*echoResponse).Text, nil
+					},
+					Deprecated:  true,
+					Unversioned: true,
+				},
+				{
+					Version:    2,
+					InputModel: func() interface{} { return new(echoRequest) },
+				},
+			},
+			Handler: echo,
+		},
+	}
+)
+
+func TestJsonRoute(t *testing.T) {
+
+	init := rpctest.New(t, nil, testRoutes...)
+	ctx := rpctest.WithUserID(context.Background(), "some-user", "someone")
+
+	var resp echoResponse
+	if err := init.CallJSON(ctx, "echo", &echoRequest{"hello"}, &resp); err != nil {
+		t.Fatalf("error while calling echo: %s", err)
+	}
+	if resp.Text != "hello" || resp.UserID != "some-user" {
+		t.Fatalf(`expected echo of "hello" by "some-user" but got %+v`, resp)
+	}
+
+	if err := init.CallJSON(ctx, "echo", &echoRequest{}, nil); err == nil {
+		t.Fatalf("expected error while calling echo with empty text, got nil")
+	}
+}
+
+func TestVersionedRoute(t *testing.T) {
+
+	init := rpctest.New(t, nil, testRoutes...)
+	ctx := rpctest.WithQueryParams(context.Background(), map[string][]string{rpc.ClientVersionParam: {"1.0.3"}})
+
+	for _, name := range []string{"echo_versioned", "echo_versioned.v1", "echo_versioned.v2"} {
+		if !init.Registered(name) {
+			t.Fatalf("expected route `%s` to be registered", name)
+		}
+	}
+
+	var text string
+	if err := init.CallJSON(ctx, "echo_versioned.v1", &echoRequestV1{"old"}, &text); err != nil {
+		t.Fatalf("error while calling echo_versioned.v1: %s", err)
+	}
+	if text != "old" {
+		t.Fatalf(`expected downgraded response "old" but got "%s"`, text)
+	}
+
+	var resp echoResponse
+	if err := init.CallJSON(ctx, "echo_versioned.v2", &echoRequest{"new"}, &resp); err != nil {
+		t.Fatalf("error while calling echo_versioned.v2: %s", err)
+	}
+	if resp.Text != "new" {
+		t.Fatalf(`expected response "new" but got "%s"`, resp.Text)
+	}
+
+	if usage := rpc.VersionUsage()["echo_versioned.v1"]; usage == 0 {
+		t.Fatalf("expected usage of echo_versioned.v1 to be counted")
+	}
+}
+
+func TestVersionedRouteDeclarations(t *testing.T) {
+
+	version := func(v int, unversioned bool) rpc.RouteVersion {
+		return rpc.RouteVersion{
+			Version:     v,
+			InputModel:  func() interface{} { return new(echoRequest) },
+			Upgrade:     func(input interface{}) (interface{}, error) { return input, nil },
+			Downgrade:   func(output interface{}) (interface{}, error) { return output, nil },
+			Unversioned: unversioned,
+		}
+	}
+
+	for _, versions := range [][]rpc.RouteVersion{
+		{version(1, false), version(1, false)},
+		{version(0, true), version(1, true)},
+	} {
+		route := &rpc.VersionedRoute{Name: "echo_declared", Versions: versions, Handler: echo}
+		if err := rpc.RegisterRoutes(rpctest.New(t, nil), []rpc.RPCRoute{route}); err == nil {
+			t.Fatalf("expected the versions %+v to be refused", versions)
+		}
+	}
+
+	// Version zero is a version like any other, served under the bare name only when marked
+	init := rpctest.New(t, nil, &rpc.VersionedRoute{Name: "echo_zero", Versions: []rpc.RouteVersion{version(0, false), version(1, false)}, Handler: echo})
+	if !init.Registered("echo_zero.v0") || init.Registered("echo_zero") {
+		t.Fatalf("expected echo_zero.v0 to be registered without the bare name")
+	}
+}
+
+func TestVersionUsageRoutes(t *testing.T) {
+
+	init := rpctest.New(t, nil, rpc.VersionUsageRoutes...)
+
+	if err := init.CallJSON(rpctest.WithUserID(context.Background(), "some-user", "someone"), "rpc_version_usage", nil, nil); err == nil {
+		t.Fatalf("expected a call on behalf of a user to be refused")
+	}
+
+	usage := map[string]uint64{}
+	if err := init.CallJSON(context.Background(), "rpc_version_usage", nil, &usage); err != nil {
+		t.Fatalf("error while calling rpc_version_usage: %s", err)
+	}
+}
+
+func TestBatchRoute(t *testing.T) {
+
+	panics := &rpc.JsonRoute{Name: "panics", Handler: func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
+		panic("something went wrong")
+	}}
+	routes := append(append([]rpc.RPCRoute{panics}, testRoutes...), rpc.BatchRoutes("Echo", "panics")...)
+
+	init := rpctest.New(t, nil, routes...)
+	ctx := context.Background()
+
+	for _, parallel := range []bool{false, true} {
+
+		var results []rpc.BatchResult
+		err := init.CallJSON(ctx, rpc.BatchRouteName, &rpc.BatchRequest{
+			Parallel: parallel,
+			Calls: []rpc.BatchCall{
+				{Name: "echo", Payload: `{"text":"first"}`},
+				{Name: "ECHO", Payload: `{"text":"second"}`},
+				{Name: "echo", Payload: `{"text":""}`},
+				{Name: "missing", Payload: ""},
+				{Name: "echo_versioned.v2", Payload: `{"text":"refused"}`},
+				{Name: rpc.BatchRouteName, Payload: `{"calls":[]}`},
+				{Name: "panics", Payload: ""},
+			},
+		}, &results)
+		if err != nil {
+			t.Fatalf("error while calling batch: %s", err)
+		}
+
+		if len(results) != 7 {
+			t.Fatalf("expected 7 results but got %d", len(results))
+		}
+		if results[0].Error != "" || results[0].Payload != `{"text":"first","userId":""}` {
+			t.Fatalf("unexpected result for first call %+v", results[0])
+		}
+		if results[1].Error != "" || results[1].Payload != `{"text":"second","userId":""}` {
+			t.Fatalf("expected names to match regardless of case but got %+v", results[1])
+		}
+		for _, result := range results[2:] {
+			if result.Error == "" {
+				t.Fatalf("expected an error for call to `%s` but got %+v", result.Name, result)
+			}
+		}
+		if results[4].Error != "route `echo_versioned.v2` cannot be called from within a batch" {
+			t.Fatalf("expected a route not allowed to be refused but got %+v", results[4])
+		}
+		if results[6].Error != "call to `panics` panicked" {
+			t.Fatalf("expected the panic to fail its own call but got %+v", results[6])
+		}
+	}
+
+	var results []rpc.BatchResult
+	calls := make([]rpc.BatchCall, rpc.MaxBatchSize+1)
+	if err := init.CallJSON(ctx, rpc.BatchRouteName, &rpc.BatchRequest{Calls: calls}, &results); err == nil {
+		t.Fatalf("expected a batch over the maximum size to be refused")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+
+	var calls []string
+
+	record := func(tag string) rpc.Middleware {
+		return func(ctx context.Context, logger runtime.Logger, name string, next func(ctx context.Context) error) error {
+			calls = append(calls, tag+":"+name)
+			return next(ctx)
+		}
+	}
+
+	init := rpctest.New(t, nil)
+	if err := rpc.RegisterRoutes(init, testRoutes[:1], record("outer"), record("inner")); err != nil {
+		t.Fatalf("error while registering routes: %s", err)
+	}
+
+	if err := init.CallJSON(context.Background(), "echo", &echoRequest{"hi"}, nil); err != nil {
+		t.Fatalf("error while calling echo: %s", err)
+	}
+
+	if len(calls) != 2 || calls[0] != "outer:echo" || calls[1] != "inner:echo" {
+		t.Fatalf("expected middleware to run outer first, got %v", calls)
+	}
+}
+
+// hookRoute registers hooks rather than an RPC, as a route may along with its RPCs
+type hookRoute struct{}
+
+func (r *hookRoute) Register(init runtime.Initializer) error {
+	if err := init.RegisterBeforeGetAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error {
+		return nil
+	}); err != nil {
+		return err
+	}
+	return init.RegisterAfterRt("ChannelJoin", func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) error {
+		return errors.New("refused")
+	})
+}
+
+func TestMiddlewareHooks(t *testing.T) {
+
+	var calls []string
+
+	record := func(ctx context.Context, logger runtime.Logger, name string, next func(ctx context.Context) error) error {
+		calls = append(calls, name)
+		return next(ctx)
+	}
+
+	init := rpctest.New(t, nil)
+	if err := rpc.RegisterRoutes(init, []rpc.RPCRoute{&hookRoute{}}, record); err != nil {
+		t.Fatalf("error while registering routes: %s", err)
+	}
+
+	before := init.Hook("BeforeGetAccount").(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error)
+	if err := before(context.Background(), init.Logger, nil, nil); err != nil {
+		t.Fatalf("error while invoking the before hook: %s", err)
+	}
+
+	after := init.Hook("AfterRtChannelJoin").(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, envelope *rtapi.Envelope) error)
+	if err := after(context.Background(), init.Logger, nil, nil, &rtapi.Envelope{}); err == nil || err.Error() != "refused" {
+		t.Fatalf("expected the error of the after hook but got %v", err)
+	}
+
+	if len(calls) != 2 || calls[0] != "BeforeGetAccount" || calls[1] != "AfterRtChannelJoin" {
+		t.Fatalf("expected the middleware to wrap the hooks, got %v", calls)
+	}
+}
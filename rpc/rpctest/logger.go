@@ -0,0 +1,28 @@
+package rpctest
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Logger is a runtime.Logger writing to the log of a test
+type Logger struct {
+	T testing.TB
+}
+
+func (l *Logger) Debug(format string, v ...interface{}) { l.T.Logf("DEBUG "+format, v...) }
+func (l *Logger) Info(format string, v ...interface{})  { l.T.Logf("INFO "+format, v...) }
+func (l *Logger) Warn(format string, v ...interface{})  { l.T.Logf("WARN "+format, v...) }
+func (l *Logger) Error(format string, v ...interface{}) { l.T.Logf("ERROR "+format, v...) }
+
+func (l *Logger) Print(v ...interface{})                 { l.T.Log(v...) }
+func (l *Logger) Println(v ...interface{})               { l.T.Log(v...) }
+func (l *Logger) Printf(format string, v ...interface{}) { l.T.Logf(format, v...) }
+
+func (l *Logger) Fatal(v ...interface{})                 { l.T.Fatal(v...) }
+func (l *Logger) Fatalln(v ...interface{})               { l.T.Fatal(v...) }
+func (l *Logger) Fatalf(format string, v ...interface{}) { l.T.Fatalf(format, v...) }
+
+func (l *Logger) Panic(v ...interface{})                 { panic(fmt.Sprint(v...)) }
+func (l *Logger) Panicln(v ...interface{})               { panic(fmt.Sprintln(v...)) }
+func (l *Logger) Panicf(format string, v ...interface{}) { panic(fmt.Sprintf(format, v...)) }
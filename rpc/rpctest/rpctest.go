@@ -0,0 +1,110 @@
+// Package rpctest invokes rpc routes in-process, without a running Nakama server
+package rpctest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc"
+)
+
+// Initializer is a runtime.Initializer capturing the RPCs and the before and after hooks registered on it,
+// any other registration panics
+type Initializer struct {
+	runtime.Initializer
+	Logger runtime.Logger
+	DB     *sql.DB
+	NK     runtime.NakamaModule
+	rpcs   map[string]func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error)
+	hooks  map[string]interface{}
+}
+
+// New creates an Initializer logging to t, with routes registered through rpc.RegisterRoutes
+func New(t testing.TB, nk runtime.NakamaModule, routes ...rpc.RPCRoute) *Initializer {
+	init := &Initializer{
+		Logger: &Logger{t},
+		NK:     nk,
+		rpcs:   make(map[string]func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error)),
+		hooks:  make(map[string]interface{}),
+	}
+	if err := rpc.RegisterRoutes(init, routes); err != nil {
+		t.Fatalf("error while registering routes: %s", err)
+	}
+	return init
+}
+
+func (i *Initializer) RegisterRpc(id string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error)) error {
+	if _, has := i.rpcs[id]; has {
+		return fmt.Errorf("rpc `%s` is already registered", id)
+	}
+	i.rpcs[id] = fn
+	return nil
+}
+
+func (i *Initializer) registerHook(name string, fn interface{}) error {
+	if _, has := i.hooks[name]; has {
+		return fmt.Errorf("hook `%s` is already registered", name)
+	}
+	i.hooks[name] = fn
+	return nil
+}
+
+// Hook returns the handler of the hook registered with name, as in `BeforeGetAccount` or `BeforeRtChannelJoin`,
+// asserting it to the handler type of the hook gives a function to invoke
+func (i *Initializer) Hook(name string) interface{} {
+	return i.hooks[name]
+}
+
+// Registered reports whether an RPC with the given name was registered
+func (i *Initializer) Registered(name string) bool {
+	_, has := i.rpcs[name]
+	return has
+}
+
+// Call invokes the RPC registered with name using a raw payload
+func (i *Initializer) Call(ctx context.Context, name string, payload string) (string, error) {
+	fn, has := i.rpcs[name]
+	if !has {
+		return "", fmt.Errorf("no rpc registered with name `%s`", name)
+	}
+	return fn(ctx, i.Logger, i.DB, i.NK, payload)
+}
+
+// CallJSON invokes the RPC registered with name, marshalling input to json and unmarshalling the response into output,
+// output may be nil to discard the response
+func (i *Initializer) CallJSON(ctx context.Context, name string, input interface{}, output interface{}) error {
+
+	bytes, err := json.Marshal(input)
+
+	if err != nil {
+		return err
+	}
+
+	response, err := i.Call(ctx, name, string(bytes))
+
+	if err != nil {
+		return err
+	}
+
+	if output == nil {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(response), output)
+}
+
+// WithUserID returns a context carrying the user identifiers Nakama sets for authenticated calls
+func WithUserID(ctx context.Context, userID string, username string) context.Context {
+	ctx = context.WithValue(ctx, runtime.RUNTIME_CTX_USER_ID, userID)
+	return context.WithValue(ctx, runtime.RUNTIME_CTX_USERNAME, username)
+}
+
+// WithQueryParams returns a context carrying the query parameters the client sent along with the call
+func WithQueryParams(ctx context.Context, params map[string][]string) context.Context {
+	return context.WithValue(ctx, runtime.RUNTIME_CTX_QUERY_PARAMS, params)
+}
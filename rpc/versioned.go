@@ -0,0 +1,166 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+const (
+	// ClientVersionParam is the query parameter clients use to report their build version
+	ClientVersionParam = "client_version"
+)
+
+var (
+	versionUsageMutex sync.Mutex
+	versionUsage      = map[string]uint64{}
+
+	// VersionUsageRoutes exposes the usage counters of versioned routes to server to server calls
+	VersionUsageRoutes = WithMiddleware([]RPCRoute{
+		&JsonRoute{Name: "rpc_version_usage", Handler: getVersionUsage},
+	}, ServerOnly)
+)
+
+// RouteVersion is a single version of a VersionedRoute's payload
+type RouteVersion struct {
+	Version    int
+	InputModel func() interface{}
+	// Upgrade adapts an input of this version to the input model of the following version
+	Upgrade func(input interface{}) (interface{}, error)
+	// Downgrade adapts an output of the following version to the output of this version
+	Downgrade func(output interface{}) (interface{}, error)
+	// Deprecated versions log a warning, along with the calling client version, on every use
+	Deprecated bool
+	// Unversioned marks the version also served under the bare name of the route, at most one version may be marked
+	Unversioned bool
+}
+
+// VersionedRoute registers a JsonRoute named `<Name>.v<Version>` for each of its versions,
+// Versions are ordered from oldest to latest, and only the latest one is handled directly by Handler
+type VersionedRoute struct {
+	Name     string
+	Versions []RouteVersion
+	Handler  func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error)
+}
+
+func (h *VersionedRoute) Register(init runtime.Initializer) error {
+
+	if len(h.Versions) == 0 {
+		return fmt.Errorf("versioned route `%s` has no versions", h.Name)
+	}
+
+	versions := map[int]bool{}
+	unversioned := false
+
+	for _, version := range h.Versions {
+		if versions[version.Version] {
+			return fmt.Errorf("version %d of route `%s` is declared more than once", version.Version, h.Name)
+		}
+		versions[version.Version] = true
+
+		if version.Unversioned {
+			if unversioned {
+				return fmt.Errorf("route `%s` marks more than one version as unversioned", h.Name)
+			}
+			unversioned = true
+		}
+	}
+
+	for i := range h.Versions {
+
+		version := h.Versions[i]
+
+		if i < len(h.Versions)-1 && (version.Upgrade == nil || version.Downgrade == nil) {
+			return fmt.Errorf("version %d of route `%s` must define both Upgrade and Downgrade", version.Version, h.Name)
+		}
+
+		route := &JsonRoute{
+			Name:       VersionName(h.Name, version.Version),
+			InputModel: version.InputModel,
+			Handler:    h.versionHandler(i),
+		}
+
+		if err := route.Register(init); err != nil {
+			return err
+		}
+
+		if version.Unversioned {
+			route.Name = h.Name
+			if err := route.Register(init); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *VersionedRoute) versionHandler(index int) func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
+
+	name := VersionName(h.Name, h.Versions[index].Version)
+
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
+
+		versionUsageMutex.Lock()
+		versionUsage[name]++
+		versionUsageMutex.Unlock()
+
+		if h.Versions[index].Deprecated {
+			userID, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+			logger.Warn("deprecated route `%s` called by user `%s` with client version `%s`", name, userID, clientVersion(ctx))
+		}
+
+		var err error
+
+		for _, version := range h.Versions[index : len(h.Versions)-1] {
+			if input, err = version.Upgrade(input); err != nil {
+				return nil, fmt.Errorf("could not upgrade input of `%s` from version %d: %s", h.Name, version.Version, err)
+			}
+		}
+
+		output, err := h.Handler(ctx, logger, db, nk, input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := len(h.Versions) - 2; i >= index; i-- {
+			if output, err = h.Versions[i].Downgrade(output); err != nil {
+				return nil, fmt.Errorf("could not downgrade output of `%s` to version %d: %s", h.Name, h.Versions[i].Version, err)
+			}
+		}
+
+		return output, nil
+	}
+}
+
+// VersionName returns the name a version of a route is registered under
+func VersionName(name string, version int) string {
+	return fmt.Sprintf("%s.v%d", name, version)
+}
+
+// VersionUsage returns the number of calls made to each version of every VersionedRoute since startup
+func VersionUsage() map[string]uint64 {
+	versionUsageMutex.Lock()
+	defer versionUsageMutex.Unlock()
+	usage := make(map[string]uint64, len(versionUsage))
+	for name, count := range versionUsage {
+		usage[name] = count
+	}
+	return usage
+}
+
+func clientVersion(ctx context.Context) string {
+	params, ok := ctx.Value(runtime.RUNTIME_CTX_QUERY_PARAMS).(map[string][]string)
+	if !ok || len(params[ClientVersionParam]) == 0 {
+		return "unknown"
+	}
+	return params[ClientVersionParam][0]
+}
+
+func getVersionUsage(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, input interface{}) (interface{}, error) {
+	return VersionUsage(), nil
+}
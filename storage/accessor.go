@@ -7,6 +7,12 @@ import (
 	"github.com/heroiclabs/nakama/runtime"
 )
 
+// Accessor is implemented by the accessors of a storage collection, describing the model stored in it
+type Accessor interface {
+	Collection() string
+	NewModel() interface{}
+}
+
 type CollectionAccessor struct {
 	CollectionID   string
 	KeyID          string
@@ -14,6 +20,14 @@ type CollectionAccessor struct {
 	DefaultFactory func() interface{}
 }
 
+func (acc *CollectionAccessor) Collection() string {
+	return acc.CollectionID
+}
+
+func (acc *CollectionAccessor) NewModel() interface{} {
+	return acc.ModelFactory()
+}
+
 func (acc *CollectionAccessor) Get(ctx context.Context, nk runtime.NakamaModule, userID string) (interface{}, bool, error) {
 
 	reads, err := nk.StorageRead(ctx, []*runtime.StorageRead{
@@ -61,20 +75,13 @@ func (acc *CollectionAccessor) GetOrDefault(ctx context.Context, nk runtime.Naka
 
 func (acc *CollectionAccessor) Save(ctx context.Context, nk runtime.NakamaModule, userID string, data interface{}) error {
 
-	bytes, err := json.Marshal(data)
+	write, err := acc.NewWrite(userID, data)
 
 	if err != nil {
 		return err
 	}
 
-	_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{
-		&runtime.StorageWrite{
-			UserID:     userID,
-			Collection: acc.CollectionID,
-			Key:        acc.KeyID,
-			Value:      string(bytes),
-		},
-	})
+	_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{write})
 
 	if err != nil {
 		return err
@@ -83,6 +90,23 @@ func (acc *CollectionAccessor) Save(ctx context.Context, nk runtime.NakamaModule
 	return nil
 }
 
+// NewWrite returns the storage write Save makes, to write along with other writes in a single StorageWrite
+func (acc *CollectionAccessor) NewWrite(userID string, data interface{}) (*runtime.StorageWrite, error) {
+
+	bytes, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &runtime.StorageWrite{
+		UserID:     userID,
+		Collection: acc.CollectionID,
+		Key:        acc.KeyID,
+		Value:      string(bytes),
+	}, nil
+}
+
 func (acc *CollectionAccessor) SaveList(ctx context.Context, nk runtime.NakamaModule, data map[string]interface{}) error {
 
 	writes := []*runtime.StorageWrite{}
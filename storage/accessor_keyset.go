@@ -13,6 +13,14 @@ type KeysetCollectionAccessor struct {
 	ModelFactory func() interface{}
 }
 
+func (acc *KeysetCollectionAccessor) Collection() string {
+	return acc.CollectionID
+}
+
+func (acc *KeysetCollectionAccessor) NewModel() interface{} {
+	return acc.ModelFactory()
+}
+
 type KeyedValue struct {
 	Key   string
 	Value interface{}
@@ -29,12 +37,14 @@ func (acc *KeysetCollectionAccessor) Get(ctx context.Context, nk runtime.NakamaM
 			return nil, err
 		}
 
-		if newCur == "" || newCur == lastCursor {
+		allObjs = append(allObjs, objs...)
+
+		// Nakama may return a cursor after the last page as well, which an empty page then follows
+		if len(objs) == 0 || newCur == "" || newCur == lastCursor {
 			break
 		}
 
 		lastCursor = newCur
-		allObjs = append(allObjs, objs...)
 	}
 
 	var res []KeyedValue
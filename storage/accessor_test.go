@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mastern2k3/poseidon/tests/fake"
+)
+
+type testStats struct {
+	MatchesPlayed uint `json:"matchesPlayed"`
+}
+
+var (
+	statsAccessor = &CollectionAccessor{
+		CollectionID:   "stats",
+		KeyID:          "matches",
+		ModelFactory:   func() interface{} { return new(testStats) },
+		DefaultFactory: func() interface{} { return &testStats{MatchesPlayed: 0} },
+	}
+
+	inventoryAccessor = &KeysetCollectionAccessor{
+		CollectionID: "inventory",
+		ModelFactory: func() interface{} { return new(testStats) },
+	}
+)
+
+func TestCollectionAccessor(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	ctx := context.Background()
+	first, second := nk.AddUser("first"), nk.AddUser("second")
+
+	if err := statsAccessor.Save(ctx, nk, first, &testStats{MatchesPlayed: 3}); err != nil {
+		t.Fatalf("error while saving stats: %s", err)
+	}
+
+	d, found, err := statsAccessor.Get(ctx, nk, first)
+	if err != nil {
+		t.Fatalf("error while getting stats: %s", err)
+	}
+	if !found || d.(*testStats).MatchesPlayed != 3 {
+		t.Fatalf("expected saved stats with 3 matches played, got %+v", d)
+	}
+
+	dx, err := statsAccessor.GetOrDefaultList(ctx, nk, []string{first, second})
+	if err != nil {
+		t.Fatalf("error while getting stats list: %s", err)
+	}
+	if len(dx) != 2 || dx[second].(*testStats).MatchesPlayed != 0 {
+		t.Fatalf("expected stats for both users with defaults for the second, got %+v", dx)
+	}
+}
+
+func TestKeysetCollectionAccessor(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	ctx := context.Background()
+	userID := nk.AddUser("someone")
+
+	values := []KeyedValue{}
+	for _, key := range []string{"a", "b", "c"} {
+		values = append(values, KeyedValue{key, &testStats{MatchesPlayed: 1}})
+	}
+
+	if err := inventoryAccessor.SaveList(ctx, nk, userID, values); err != nil {
+		t.Fatalf("error while saving inventory: %s", err)
+	}
+
+	if err := inventoryAccessor.Delete(ctx, nk, "b", userID); err != nil {
+		t.Fatalf("error while deleting from inventory: %s", err)
+	}
+
+	kvs, err := inventoryAccessor.Get(ctx, nk, userID)
+	if err != nil {
+		t.Fatalf("error while getting inventory: %s", err)
+	}
+	if len(kvs) != 2 || kvs[0].Key != "a" || kvs[1].Key != "c" {
+		t.Fatalf("expected keys a and c to remain, got %+v", kvs)
+	}
+}
@@ -0,0 +1,230 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/heroiclabs/nakama/api"
+)
+
+// leaderboard keeps records without expiry, reset schedules are ignored. Tournaments also limit the number of
+// scores written to a record and who may write them, while their start, end and duration are ignored.
+type leaderboard struct {
+	ascending bool
+	operator  string
+	records   map[string]*api.LeaderboardRecord
+
+	tournament   bool
+	maxNumScore  int
+	joinRequired bool
+	joined       map[string]bool
+}
+
+func (nk *NakamaModule) LeaderboardCreate(ctx context.Context, id string, authoritative bool, sortOrder, operator, resetSchedule string, metadata map[string]interface{}) error {
+	if id == "" {
+		return errors.New("expects a leaderboard ID string")
+	}
+	if sortOrder != "asc" && sortOrder != "desc" {
+		return errors.New("expects sort order to be 'asc' or 'desc'")
+	}
+	if operator != "best" && operator != "set" && operator != "incr" {
+		return errors.New("expects sort order to be 'best', 'set', or 'incr'")
+	}
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	if _, has := nk.leaderboards[id]; !has {
+		nk.leaderboards[id] = &leaderboard{
+			ascending: sortOrder == "asc",
+			operator:  operator,
+			records:   make(map[string]*api.LeaderboardRecord),
+		}
+	}
+	return nil
+}
+
+func (nk *NakamaModule) TournamentCreate(ctx context.Context, id string, sortOrder, operator, resetSchedule string, metadata map[string]interface{}, title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool) error {
+	if err := nk.LeaderboardCreate(ctx, id, true, sortOrder, operator, resetSchedule, metadata); err != nil {
+		return err
+	}
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	board := nk.leaderboards[id]
+	board.tournament = true
+	board.maxNumScore = maxNumScore
+	board.joinRequired = joinRequired
+	board.joined = make(map[string]bool)
+	return nil
+}
+
+func (nk *NakamaModule) TournamentJoin(ctx context.Context, id, ownerID, username string) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	board, has := nk.leaderboards[id]
+	if !has || !board.tournament {
+		return errors.New("tournament not found")
+	}
+	board.joined[ownerID] = true
+	return nil
+}
+
+func (nk *NakamaModule) TournamentRecordWrite(ctx context.Context, id, ownerID, username string, score, subscore int64, metadata map[string]interface{}) (*api.LeaderboardRecord, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	board, has := nk.leaderboards[id]
+	if !has || !board.tournament {
+		return nil, errors.New("tournament not found")
+	}
+	if board.joinRequired && !board.joined[ownerID] {
+		return nil, errors.New("required to join before writing tournament record")
+	}
+	if record, has := board.records[ownerID]; has && record.MaxNumScore > 0 && uint32(record.NumScore) >= record.MaxNumScore {
+		return nil, errors.New("max number score count reached")
+	}
+
+	return nk.writeRecord(board, id, ownerID, username, score, subscore, metadata)
+}
+
+func (nk *NakamaModule) LeaderboardRecordWrite(ctx context.Context, id, ownerID, username string, score, subscore int64, metadata map[string]interface{}) (*api.LeaderboardRecord, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	board, has := nk.leaderboards[id]
+	if !has {
+		return nil, errors.New("leaderboard not found")
+	}
+
+	return nk.writeRecord(board, id, ownerID, username, score, subscore, metadata)
+}
+
+func (nk *NakamaModule) writeRecord(board *leaderboard, id, ownerID, username string, score, subscore int64, metadata map[string]interface{}) (*api.LeaderboardRecord, error) {
+
+	bytes, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	now := nk.timestamp()
+	record, has := board.records[ownerID]
+
+	if !has {
+		record = &api.LeaderboardRecord{
+			LeaderboardId: id,
+			OwnerId:       ownerID,
+			Score:         score,
+			Subscore:      subscore,
+			MaxNumScore:   uint32(board.maxNumScore),
+			CreateTime:    now,
+		}
+		board.records[ownerID] = record
+	} else {
+		switch board.operator {
+		case "set":
+			record.Score, record.Subscore = score, subscore
+		case "incr":
+			record.Score, record.Subscore = record.Score+score, record.Subscore+subscore
+		case "best":
+			if board.better(score, subscore, record.Score, record.Subscore) {
+				record.Score, record.Subscore = score, subscore
+			}
+		}
+	}
+
+	if username != "" {
+		record.Username = &wrappers.StringValue{Value: username}
+	}
+	record.NumScore++
+	record.Metadata = string(bytes)
+	record.UpdateTime = now
+
+	board.rank()
+
+	out := *record
+	return &out, nil
+}
+
+func (nk *NakamaModule) LeaderboardRecordDelete(ctx context.Context, id, ownerID string) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	board, has := nk.leaderboards[id]
+	if !has {
+		return errors.New("leaderboard not found")
+	}
+	delete(board.records, ownerID)
+	if board.tournament {
+		delete(board.joined, ownerID)
+	}
+	board.rank()
+	return nil
+}
+
+// LeaderboardRecordsList pages through records using the rank to start after as the cursor, expiry is ignored
+func (nk *NakamaModule) LeaderboardRecordsList(ctx context.Context, id string, ownerIDs []string, limit int, cursor string, expiry int64) ([]*api.LeaderboardRecord, []*api.LeaderboardRecord, string, string, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	board, has := nk.leaderboards[id]
+	if !has {
+		return nil, nil, "", "", errors.New("leaderboard not found")
+	}
+
+	start := int64(0)
+	if cursor != "" {
+		if _, err := fmt.Sscanf(cursor, "%d", &start); err != nil {
+			return nil, nil, "", "", errors.New("invalid cursor")
+		}
+	}
+
+	records := []*api.LeaderboardRecord{}
+	next := ""
+
+	for _, record := range board.sorted() {
+		if record.Rank <= start {
+			continue
+		}
+		if len(records) == limit {
+			next = fmt.Sprintf("%d", start+int64(limit))
+			break
+		}
+		out := *record
+		records = append(records, &out)
+	}
+
+	ownerRecords := []*api.LeaderboardRecord{}
+	for _, ownerID := range ownerIDs {
+		if record, has := board.records[ownerID]; has {
+			out := *record
+			ownerRecords = append(ownerRecords, &out)
+		}
+	}
+
+	return records, ownerRecords, next, "", nil
+}
+
+func (b *leaderboard) better(score, subscore, otherScore, otherSubscore int64) bool {
+	if score == otherScore {
+		return (subscore < otherSubscore) == b.ascending && subscore != otherSubscore
+	}
+	return (score < otherScore) == b.ascending
+}
+
+func (b *leaderboard) sorted() []*api.LeaderboardRecord {
+	records := []*api.LeaderboardRecord{}
+	for _, record := range b.records {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return b.better(records[i].Score, records[i].Subscore, records[j].Score, records[j].Subscore)
+	})
+	return records
+}
+
+func (b *leaderboard) rank() {
+	for i, record := range b.sorted() {
+		record.Rank = int64(i + 1)
+	}
+}
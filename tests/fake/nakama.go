@@ -0,0 +1,280 @@
+// Package fake provides an in-memory runtime.NakamaModule for unit tests that do not require a running Nakama server
+package fake
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+// NakamaModule keeps users, storage, wallets, leaderboards, notifications, matches and streams in memory,
+// calling any method it does not implement panics
+type NakamaModule struct {
+	runtime.NakamaModule
+
+	// Now returns the time used for create and update times, defaults to time.Now
+	Now func() time.Time
+
+	mutex         sync.Mutex
+	accounts      map[string]*api.Account
+	banned        map[string]bool
+	storage       map[storageID]*api.StorageObject
+	ledger        []*ledgerItem
+	notifications map[string][]*api.Notification
+	leaderboards  map[string]*leaderboard
+	matches       []*api.Match
+	streams       map[streamID][]*presence
+	streamData    map[streamID][]string
+}
+
+// NewNakamaModule creates an empty NakamaModule
+func NewNakamaModule() *NakamaModule {
+	return &NakamaModule{
+		Now:           time.Now,
+		accounts:      make(map[string]*api.Account),
+		banned:        make(map[string]bool),
+		storage:       make(map[storageID]*api.StorageObject),
+		notifications: make(map[string][]*api.Notification),
+		leaderboards:  make(map[string]*leaderboard),
+		streams:       make(map[streamID][]*presence),
+		streamData:    make(map[streamID][]string),
+	}
+}
+
+// AddUser creates a user with the given username and returns its id
+func (nk *NakamaModule) AddUser(username string) string {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	return nk.addUser(username).GetUser().GetId()
+}
+
+// AddAccount stores a copy of a fully populated account, generating a user id if it has none
+func (nk *NakamaModule) AddAccount(account *api.Account) string {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	acc := *account
+	user := *account.GetUser()
+	if user.Id == "" {
+		user.Id = newID()
+	}
+	if user.CreateTime == nil {
+		user.CreateTime = nk.timestamp()
+		user.UpdateTime = user.CreateTime
+	}
+	if acc.Wallet == "" {
+		acc.Wallet = "{}"
+	}
+	acc.User = &user
+	nk.accounts[user.Id] = &acc
+	return user.Id
+}
+
+// Banned reports whether a user was banned through UsersBanId
+func (nk *NakamaModule) Banned(userID string) bool {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	return nk.banned[userID]
+}
+
+func (nk *NakamaModule) addUser(username string) *api.Account {
+	now := nk.timestamp()
+	id := newID()
+	if username == "" {
+		username = id[:10]
+	}
+	acc := &api.Account{
+		User: &api.User{
+			Id:         id,
+			Username:   username,
+			Metadata:   "{}",
+			CreateTime: now,
+			UpdateTime: now,
+		},
+		Wallet: "{}",
+	}
+	nk.accounts[id] = acc
+	return acc
+}
+
+func (nk *NakamaModule) timestamp() *timestamp.Timestamp {
+	return &timestamp.Timestamp{Seconds: nk.Now().Unix()}
+}
+
+func (nk *NakamaModule) authenticate(create bool, username string, match func(acc *api.Account) bool, init func(acc *api.Account)) (string, string, bool, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	for id, acc := range nk.accounts {
+		if match(acc) {
+			return id, acc.GetUser().GetUsername(), false, nil
+		}
+	}
+	if !create {
+		return "", "", false, fmt.Errorf("user account not found")
+	}
+	for _, acc := range nk.accounts {
+		if username != "" && acc.GetUser().GetUsername() == username {
+			return "", "", false, fmt.Errorf("username is already in use")
+		}
+	}
+	acc := nk.addUser(username)
+	init(acc)
+	return acc.GetUser().GetId(), acc.GetUser().GetUsername(), true, nil
+}
+
+func (nk *NakamaModule) AuthenticateCustom(ctx context.Context, id, username string, create bool) (string, string, bool, error) {
+	return nk.authenticate(create, username,
+		func(acc *api.Account) bool { return acc.GetCustomId() == id },
+		func(acc *api.Account) { acc.CustomId = id },
+	)
+}
+
+func (nk *NakamaModule) AuthenticateDevice(ctx context.Context, id, username string, create bool) (string, string, bool, error) {
+	return nk.authenticate(create, username,
+		func(acc *api.Account) bool {
+			for _, device := range acc.GetDevices() {
+				if device.GetId() == id {
+					return true
+				}
+			}
+			return false
+		},
+		func(acc *api.Account) { acc.Devices = append(acc.Devices, &api.AccountDevice{Id: id}) },
+	)
+}
+
+func (nk *NakamaModule) AuthenticateEmail(ctx context.Context, email, password, username string, create bool) (string, string, bool, error) {
+	return nk.authenticate(create, username,
+		func(acc *api.Account) bool { return acc.GetEmail() == email },
+		func(acc *api.Account) { acc.Email = email },
+	)
+}
+
+func (nk *NakamaModule) AccountGetId(ctx context.Context, userID string) (*api.Account, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	acc, has := nk.accounts[userID]
+	if !has {
+		return nil, fmt.Errorf("account not found")
+	}
+	return copyAccount(acc), nil
+}
+
+func (nk *NakamaModule) AccountsGetId(ctx context.Context, userIDs []string) ([]*api.Account, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	accounts := []*api.Account{}
+	for _, userID := range userIDs {
+		if acc, has := nk.accounts[userID]; has {
+			accounts = append(accounts, copyAccount(acc))
+		}
+	}
+	return accounts, nil
+}
+
+func (nk *NakamaModule) AccountUpdateId(ctx context.Context, userID, username string, metadata map[string]interface{}, displayName, timezone, location, langTag, avatarUrl string) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	acc, has := nk.accounts[userID]
+	if !has {
+		return fmt.Errorf("account not found")
+	}
+	user := acc.GetUser()
+	if username != "" {
+		user.Username = username
+	}
+	if metadata != nil {
+		bytes, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		user.Metadata = string(bytes)
+	}
+	if displayName != "" {
+		user.DisplayName = displayName
+	}
+	if timezone != "" {
+		user.Timezone = timezone
+	}
+	if location != "" {
+		user.Location = location
+	}
+	if langTag != "" {
+		user.LangTag = langTag
+	}
+	if avatarUrl != "" {
+		user.AvatarUrl = avatarUrl
+	}
+	user.UpdateTime = nk.timestamp()
+	return nil
+}
+
+func (nk *NakamaModule) UsersGetId(ctx context.Context, userIDs []string) ([]*api.User, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	users := []*api.User{}
+	for _, userID := range userIDs {
+		if acc, has := nk.accounts[userID]; has {
+			user := *acc.GetUser()
+			users = append(users, &user)
+		}
+	}
+	return users, nil
+}
+
+func (nk *NakamaModule) UsersGetUsername(ctx context.Context, usernames []string) ([]*api.User, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	users := []*api.User{}
+	for _, username := range usernames {
+		for _, acc := range nk.accounts {
+			if acc.GetUser().GetUsername() == username {
+				user := *acc.GetUser()
+				users = append(users, &user)
+			}
+		}
+	}
+	return users, nil
+}
+
+func (nk *NakamaModule) UsersBanId(ctx context.Context, userIDs []string) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	for _, userID := range userIDs {
+		nk.banned[userID] = true
+	}
+	return nil
+}
+
+func (nk *NakamaModule) UsersUnbanId(ctx context.Context, userIDs []string) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	for _, userID := range userIDs {
+		delete(nk.banned, userID)
+	}
+	return nil
+}
+
+func copyAccount(acc *api.Account) *api.Account {
+	copied := *acc
+	user := *acc.GetUser()
+	copied.User = &user
+	copied.Devices = append([]*api.AccountDevice(nil), acc.GetDevices()...)
+	return &copied
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
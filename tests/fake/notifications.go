@@ -0,0 +1,62 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+func (nk *NakamaModule) NotificationSend(ctx context.Context, userID, subject string, content map[string]interface{}, code int, sender string, persistent bool) error {
+	return nk.NotificationsSend(ctx, []*runtime.NotificationSend{
+		&runtime.NotificationSend{
+			UserID:     userID,
+			Subject:    subject,
+			Content:    content,
+			Code:       code,
+			Sender:     sender,
+			Persistent: persistent,
+		},
+	})
+}
+
+func (nk *NakamaModule) NotificationsSend(ctx context.Context, notifications []*runtime.NotificationSend) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	for _, n := range notifications {
+		if n.Subject == "" {
+			return fmt.Errorf("expects subject to be a non-empty string")
+		}
+		if n.Code <= 0 {
+			return fmt.Errorf("expects code to number above 0")
+		}
+	}
+
+	for _, n := range notifications {
+		bytes, err := json.Marshal(n.Content)
+		if err != nil {
+			return err
+		}
+		nk.notifications[n.UserID] = append(nk.notifications[n.UserID], &api.Notification{
+			Id:         newID(),
+			Subject:    n.Subject,
+			Content:    string(bytes),
+			Code:       int32(n.Code),
+			SenderId:   n.Sender,
+			CreateTime: nk.timestamp(),
+			Persistent: n.Persistent,
+		})
+	}
+
+	return nil
+}
+
+// Notifications returns the notifications sent to a user, persistent or not, oldest first
+func (nk *NakamaModule) Notifications(userID string) []*api.Notification {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	return append([]*api.Notification(nil), nk.notifications[userID]...)
+}
@@ -0,0 +1,172 @@
+package fake
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+type storageID struct {
+	collection, key, userID string
+}
+
+func (nk *NakamaModule) StorageList(ctx context.Context, userID, collection string, limit int, cursor string) ([]*api.StorageObject, string, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	after := ""
+	if cursor != "" {
+		bytes, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("malformed cursor was used")
+		}
+		after = string(bytes)
+	}
+
+	keys := []string{}
+	for id := range nk.storage {
+		if id.collection == collection && id.userID == userID && (after == "" || id.key > after) {
+			keys = append(keys, id.key)
+		}
+	}
+	sort.Strings(keys)
+
+	more := limit > 0 && len(keys) > limit
+	if more {
+		keys = keys[:limit]
+	}
+
+	objs := make([]*api.StorageObject, 0, len(keys))
+	for _, key := range keys {
+		objs = append(objs, copyObject(nk.storage[storageID{collection, key, userID}]))
+	}
+
+	// A cursor is only returned while objects remain past the page
+	next := ""
+	if more {
+		next = base64.RawURLEncoding.EncodeToString([]byte(keys[len(keys)-1]))
+	}
+
+	return objs, next, nil
+}
+
+func (nk *NakamaModule) StorageRead(ctx context.Context, reads []*runtime.StorageRead) ([]*api.StorageObject, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	objs := make([]*api.StorageObject, 0, len(reads))
+	for _, read := range reads {
+		if read.Collection == "" {
+			return nil, fmt.Errorf("expects collection to be a non-empty string")
+		}
+		if read.Key == "" {
+			return nil, fmt.Errorf("expects key to be a non-empty string")
+		}
+		if obj, has := nk.storage[storageID{read.Collection, read.Key, read.UserID}]; has {
+			objs = append(objs, copyObject(obj))
+		}
+	}
+
+	return objs, nil
+}
+
+func (nk *NakamaModule) StorageWrite(ctx context.Context, writes []*runtime.StorageWrite) ([]*api.StorageObjectAck, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	for _, write := range writes {
+		if write.Collection == "" {
+			return nil, fmt.Errorf("expects collection to be a non-empty string")
+		}
+		if write.Key == "" {
+			return nil, fmt.Errorf("expects key to be a non-empty string")
+		}
+		var valueMap map[string]interface{}
+		if err := json.Unmarshal([]byte(write.Value), &valueMap); err != nil {
+			return nil, fmt.Errorf("value must be a JSON-encoded object")
+		}
+		if write.PermissionRead < 0 || write.PermissionRead > 2 || write.PermissionWrite < 0 || write.PermissionWrite > 1 {
+			return nil, fmt.Errorf("invalid permissions for object `%s/%s`", write.Collection, write.Key)
+		}
+		if err := nk.checkVersion(storageID{write.Collection, write.Key, write.UserID}, write.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	acks := make([]*api.StorageObjectAck, 0, len(writes))
+	for _, write := range writes {
+		id := storageID{write.Collection, write.Key, write.UserID}
+		sum := md5.Sum([]byte(write.Value))
+		now := nk.timestamp()
+		obj, has := nk.storage[id]
+		if !has {
+			obj = &api.StorageObject{
+				Collection: write.Collection,
+				Key:        write.Key,
+				UserId:     write.UserID,
+				CreateTime: now,
+			}
+			nk.storage[id] = obj
+		}
+		obj.Value = write.Value
+		obj.Version = hex.EncodeToString(sum[:])
+		obj.PermissionRead = int32(write.PermissionRead)
+		obj.PermissionWrite = int32(write.PermissionWrite)
+		obj.UpdateTime = now
+		acks = append(acks, &api.StorageObjectAck{
+			Collection: obj.Collection,
+			Key:        obj.Key,
+			Version:    obj.Version,
+			UserId:     obj.UserId,
+		})
+	}
+
+	return acks, nil
+}
+
+func (nk *NakamaModule) StorageDelete(ctx context.Context, deletes []*runtime.StorageDelete) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	for _, del := range deletes {
+		if del.Version == "" {
+			continue
+		}
+		if obj, has := nk.storage[storageID{del.Collection, del.Key, del.UserID}]; !has || obj.Version != del.Version {
+			return fmt.Errorf("storage delete rejected - not found or version check failed for `%s/%s`", del.Collection, del.Key)
+		}
+	}
+
+	for _, del := range deletes {
+		delete(nk.storage, storageID{del.Collection, del.Key, del.UserID})
+	}
+
+	return nil
+}
+
+// checkVersion applies Nakama's optimistic concurrency rules, where an empty version always matches,
+// `*` only matches when there is no existing object and any other version must equal the existing one
+func (nk *NakamaModule) checkVersion(id storageID, version string) error {
+	obj, has := nk.storage[id]
+	switch {
+	case version == "":
+		return nil
+	case version == "*" && has:
+		return fmt.Errorf("storage write rejected - version check failed for `%s/%s`", id.collection, id.key)
+	case version != "*" && (!has || obj.Version != version):
+		return fmt.Errorf("storage write rejected - version check failed for `%s/%s`", id.collection, id.key)
+	}
+	return nil
+}
+
+func copyObject(obj *api.StorageObject) *api.StorageObject {
+	copied := *obj
+	return &copied
+}
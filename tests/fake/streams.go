@@ -0,0 +1,141 @@
+package fake
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/heroiclabs/nakama/api"
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+type streamID struct {
+	mode       uint8
+	subject    string
+	subcontext string
+	label      string
+}
+
+type presence struct {
+	userID      string
+	sessionID   string
+	username    string
+	status      string
+	hidden      bool
+	persistence bool
+}
+
+func (p *presence) GetHidden() bool      { return p.hidden }
+func (p *presence) GetPersistence() bool { return p.persistence }
+func (p *presence) GetUsername() string  { return p.username }
+func (p *presence) GetStatus() string    { return p.status }
+func (p *presence) GetUserId() string    { return p.userID }
+func (p *presence) GetSessionId() string { return p.sessionID }
+func (p *presence) GetNodeId() string    { return "fake" }
+
+// AddMatch lists a running match, its id is generated the way Nakama does if the match has none
+func (nk *NakamaModule) AddMatch(match *api.Match) string {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	m := *match
+	if m.MatchId == "" {
+		m.MatchId = newID() + "."
+		if m.Authoritative {
+			m.MatchId += "fake"
+		}
+	}
+	nk.matches = append(nk.matches, &m)
+	return m.MatchId
+}
+
+// StreamData returns the data sent to a stream so far
+func (nk *NakamaModule) StreamData(mode uint8, subject, subcontext, label string) []string {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	return append([]string{}, nk.streamData[streamID{mode, subject, subcontext, label}]...)
+}
+
+// MatchList filters the added matches, the query over labels is ignored
+func (nk *NakamaModule) MatchList(ctx context.Context, limit int, authoritative bool, label string, minSize, maxSize int, query string) ([]*api.Match, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	matches := []*api.Match{}
+	for _, match := range nk.matches {
+		if len(matches) == limit {
+			break
+		}
+		if match.Authoritative != authoritative || int(match.Size) < minSize || int(match.Size) > maxSize {
+			continue
+		}
+		if label != "" && match.GetLabel().GetValue() != label {
+			continue
+		}
+		out := *match
+		if match.Label != nil {
+			out.Label = &wrappers.StringValue{Value: match.Label.Value}
+		}
+		matches = append(matches, &out)
+	}
+
+	return matches, nil
+}
+
+func (nk *NakamaModule) StreamUserJoin(mode uint8, subject, subcontext, label, userID, sessionID string, hidden, persistence bool, status string) (bool, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	acc, has := nk.accounts[userID]
+	if !has {
+		return false, errors.New("expects valid user id")
+	}
+
+	id := streamID{mode, subject, subcontext, label}
+	for _, p := range nk.streams[id] {
+		if p.userID == userID && p.sessionID == sessionID {
+			p.hidden, p.persistence, p.status = hidden, persistence, status
+			return false, nil
+		}
+	}
+	nk.streams[id] = append(nk.streams[id], &presence{userID, sessionID, acc.GetUser().GetUsername(), status, hidden, persistence})
+
+	return true, nil
+}
+
+func (nk *NakamaModule) StreamUserList(mode uint8, subject, subcontext, label string, includeHidden, includeNotHidden bool) ([]runtime.Presence, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	presences := []runtime.Presence{}
+	for _, p := range nk.streams[streamID{mode, subject, subcontext, label}] {
+		if (p.hidden && includeHidden) || (!p.hidden && includeNotHidden) {
+			out := *p
+			presences = append(presences, &out)
+		}
+	}
+
+	return presences, nil
+}
+
+func (nk *NakamaModule) StreamUserLeave(mode uint8, subject, subcontext, label, userID, sessionID string) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	id := streamID{mode, subject, subcontext, label}
+	for i, p := range nk.streams[id] {
+		if p.userID == userID && p.sessionID == sessionID {
+			nk.streams[id] = append(nk.streams[id][:i], nk.streams[id][i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (nk *NakamaModule) StreamSend(mode uint8, subject, subcontext, label, data string) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+	id := streamID{mode, subject, subcontext, label}
+	nk.streamData[id] = append(nk.streamData[id], data)
+	return nil
+}
@@ -0,0 +1,173 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+type ledgerItem struct {
+	ID         string
+	UserID     string
+	Changeset  map[string]interface{}
+	Metadata   map[string]interface{}
+	CreateTime int64
+	UpdateTime int64
+}
+
+func (i *ledgerItem) GetID() string                        { return i.ID }
+func (i *ledgerItem) GetUserID() string                    { return i.UserID }
+func (i *ledgerItem) GetCreateTime() int64                 { return i.CreateTime }
+func (i *ledgerItem) GetUpdateTime() int64                 { return i.UpdateTime }
+func (i *ledgerItem) GetChangeset() map[string]interface{} { return i.Changeset }
+func (i *ledgerItem) GetMetadata() map[string]interface{}  { return i.Metadata }
+
+func (nk *NakamaModule) WalletUpdate(ctx context.Context, userID string, changeset, metadata map[string]interface{}, updateLedger bool) error {
+	return nk.WalletsUpdate(ctx, []*runtime.WalletUpdate{
+		&runtime.WalletUpdate{
+			UserID:    userID,
+			Changeset: changeset,
+			Metadata:  metadata,
+		},
+	}, updateLedger)
+}
+
+func (nk *NakamaModule) WalletsUpdate(ctx context.Context, updates []*runtime.WalletUpdate, updateLedger bool) error {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	wallets := map[string]map[string]interface{}{}
+	items := []*ledgerItem{}
+
+	for _, update := range updates {
+		acc, has := nk.accounts[update.UserID]
+		if !has {
+			// Nakama skips updates to users that do not exist
+			continue
+		}
+		wallet, has := wallets[update.UserID]
+		if !has {
+			if err := json.Unmarshal([]byte(acc.GetWallet()), &wallet); err != nil {
+				return err
+			}
+		}
+		// Values round-trip through json, as they would through the database
+		var changeset, metadata map[string]interface{}
+		if err := roundTrip(update.Changeset, &changeset); err != nil {
+			return err
+		}
+		if err := roundTrip(update.Metadata, &metadata); err != nil {
+			return err
+		}
+		wallet, err := applyWalletUpdate(wallet, changeset, "")
+		if err != nil {
+			return err
+		}
+		wallets[update.UserID] = wallet
+		if updateLedger {
+			now := nk.Now().Unix()
+			items = append(items, &ledgerItem{newID(), update.UserID, changeset, metadata, now, now})
+		}
+	}
+
+	for userID, wallet := range wallets {
+		bytes, err := json.Marshal(wallet)
+		if err != nil {
+			return err
+		}
+		nk.accounts[userID].Wallet = string(bytes)
+	}
+
+	nk.ledger = append(nk.ledger, items...)
+
+	return nil
+}
+
+func (nk *NakamaModule) WalletLedgerUpdate(ctx context.Context, itemID string, metadata map[string]interface{}) (runtime.WalletLedgerItem, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	var update map[string]interface{}
+	if err := roundTrip(metadata, &update); err != nil {
+		return nil, err
+	}
+
+	for _, item := range nk.ledger {
+		if item.ID == itemID {
+			if item.Metadata == nil {
+				item.Metadata = map[string]interface{}{}
+			}
+			for k, v := range update {
+				item.Metadata[k] = v
+			}
+			item.UpdateTime = nk.Now().Unix()
+			return item, nil
+		}
+	}
+
+	return nil, fmt.Errorf("wallet ledger item `%s` not found", itemID)
+}
+
+func (nk *NakamaModule) WalletLedgerList(ctx context.Context, userID string) ([]runtime.WalletLedgerItem, error) {
+	nk.mutex.Lock()
+	defer nk.mutex.Unlock()
+
+	items := []runtime.WalletLedgerItem{}
+	for _, item := range nk.ledger {
+		if item.UserID == userID {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// applyWalletUpdate follows the rules of Nakama's wallet updates, adding numeric values,
+// merging nested maps and rejecting any update that would leave a negative value
+func applyWalletUpdate(wallet map[string]interface{}, changeset map[string]interface{}, path string) (map[string]interface{}, error) {
+	if wallet == nil {
+		wallet = map[string]interface{}{}
+	}
+	for k, v := range changeset {
+		currentPath := k
+		if path != "" {
+			currentPath = path + "." + k
+		}
+		switch change := v.(type) {
+		case map[string]interface{}:
+			existing, has := wallet[k]
+			existingMap, ok := existing.(map[string]interface{})
+			if has && !ok {
+				return nil, fmt.Errorf("update changeset does not match existing wallet value map type at path '%v'", currentPath)
+			}
+			updated, err := applyWalletUpdate(existingMap, change, currentPath)
+			if err != nil {
+				return nil, err
+			}
+			wallet[k] = updated
+		case float64:
+			existing, has := wallet[k]
+			existingValue, ok := existing.(float64)
+			if has && !ok {
+				return nil, fmt.Errorf("update changeset does not match existing wallet value number type at path '%v'", currentPath)
+			}
+			if existingValue+change < 0 {
+				return nil, fmt.Errorf("wallet update rejected negative value at path '%v'", currentPath)
+			}
+			wallet[k] = existingValue + change
+		default:
+			return nil, fmt.Errorf("unknown update changeset value type at path '%v', expecting map or float64", currentPath)
+		}
+	}
+	return wallet, nil
+}
+
+func roundTrip(in interface{}, out interface{}) error {
+	bytes, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, out)
+}
@@ -7,5 +7,7 @@ import "github.com/gobuffalo/packr"
 // You can use the "packr clean" command to clean up this,
 // and any other packr generated files.
 func init() {
-	packr.PackJSONBytes("./static", "index.html", "\"PCFET0NUWVBFIGh0bWw+CjxodG1sPgogIDxoZWFkPgogICAgPHN0eWxlPgogICAgICBib2R5IHsKICAgICAgICBoZWlnaHQ6IDEwMCU7CiAgICAgICAgbWFyZ2luOiAwOwogICAgICAgIHdpZHRoOiAxMDAlOwogICAgICAgIG92ZXJmbG93OiBoaWRkZW47CiAgICAgIH0KICAgICAgI2dyYXBoaXFsIHsKICAgICAgICBoZWlnaHQ6IDEwMHZoOwogICAgICB9CiAgICA8L3N0eWxlPgoKICAgIDxzY3JpcHQgc3JjPSIvL2Nkbi5qc2RlbGl2ci5uZXQvZXM2LXByb21pc2UvNC4wLjUvZXM2LXByb21pc2UuYXV0by5taW4uanMiPjwvc2NyaXB0PgogICAgPHNjcmlwdCBzcmM9Ii8vY2RuLmpzZGVsaXZyLm5ldC9mZXRjaC8wLjkuMC9mZXRjaC5taW4uanMiPjwvc2NyaXB0PgogICAgPHNjcmlwdCBzcmM9Ii8vY2RuLmpzZGVsaXZyLm5ldC9yZWFjdC8xNS40LjIvcmVhY3QubWluLmpzIj48L3NjcmlwdD4KICAgIDxzY3JpcHQgc3JjPSIvL2Nkbi5qc2RlbGl2ci5uZXQvcmVhY3QvMTUuNC4yL3JlYWN0LWRvbS5taW4uanMiPjwvc2NyaXB0PgoKICAgIDxsaW5rIHJlbD0ic3R5bGVzaGVldCIgaHJlZj0iaHR0cHM6Ly9jZG5qcy5jbG91ZGZsYXJlLmNvbS9hamF4L2xpYnMvZ3JhcGhpcWwvMC4xMi4wL2dyYXBoaXFsLmNzcyIgLz4KICAgIDxzY3JpcHQgc3JjPSJodHRwczovL2NkbmpzLmNsb3VkZmxhcmUuY29tL2FqYXgvbGlicy9ncmFwaGlxbC8wLjEyLjAvZ3JhcGhpcWwuanMiIGNoYXJzZXQ9InV0Zi04Ij48L3NjcmlwdD4KCiAgPC9oZWFkPgogIDxib2R5PgogICAgPGRpdiBpZD0iZ3JhcGhpcWwiPkxvYWRpbmcuLi48L2Rpdj4KICAgIDxzY3JpcHQ+CiAgICAgIC8vIFBhcnNlIHRoZSBzZWFyY2ggc3RyaW5nIHRvIGdldCB1cmwgcGFyYW1ldGVycy4KICAgICAgdmFyIHNlYXJjaCA9IHdpbmRvdy5sb2NhdGlvbi5zZWFyY2g7CiAgICAgIHZhciBob3N0bmFtZSA9IHdpbmRvdy5sb2NhdGlvbi5ob3N0bmFtZTsKICAgICAgdmFyIHBhcmFtZXRlcnMgPSB7fTsKCiAgICAgIHNlYXJjaC5zdWJzdHIoMSkuc3BsaXQoJyYnKS5mb3JFYWNoKGZ1bmN0aW9uIChlbnRyeSkgewogICAgICAgIHZhciBlcSA9IGVudHJ5LmluZGV4T2YoJz0nKTsKICAgICAgICBpZiAoZXEgPj0gMCkgewogICAgICAgICAgcGFyYW1ldGVyc1tkZWNvZGVVUklDb21wb25lbnQoZW50cnkuc2xpY2UoMCwgZXEpKV0gPQogICAgICAgICAgICBkZWNvZGVVUklDb21wb25lbnQoZW50cnkuc2xpY2UoZXEgKyAxKSk7CiAgICAgICAgfQogICAgICB9KTsKCiAgICAgIC8vIGlmIHZhcmlhYmxlcyB3YXMgcHJvdmlkZWQsIHRyeSB0byBmb3JtYXQgaXQuCiAgICAgIGlmIChwYXJhbWV0ZXJzLnZhcmlhYmxlcykgewogICAgICAgIHRyeSB7CiAgICAgICAgICBwYXJhbWV0ZXJzLnZhcmlhYmxlcyA9CiAgICAgICAgICAgIEpTT04uc3RyaW5naWZ5KEpTT04ucGFyc2UocGFyYW1ldGVycy52YXJpYWJsZXMpLCBudWxsLCAyKTsKICAgICAgICB9IGNhdGNoIChlKSB7CiAgICAgICAgICAvLyBEbyBub3RoaW5nLCB3ZSB3YW50IHRvIGRpc3BsYXkgdGhlIGludmFsaWQgSlNPTiBhcyBhIHN0cmluZywgcmF0aGVyCiAgICAgICAgICAvLyB0aGFuIHByZXNlbnQgYW4gZXJyb3IuCiAgICAgICAgfQogICAgICB9CgogICAgICAvLyBXaGVuIHRoZSBxdWVyeSBhbmQgdmFyaWFibGVzIHN0cmluZyBpcyBlZGl0ZWQsIHVwZGF0ZSB0aGUgVVJMIGJhciBzbwogICAgICAvLyB0aGF0IGl0IGNhbiBiZSBlYXNpbHkgc2hhcmVkCiAgICAgIGZ1bmN0aW9uIG9uRWRpdFF1ZXJ5KG5ld1F1ZXJ5KSB7CiAgICAgICAgcGFyYW1ldGVycy5xdWVyeSA9IG5ld1F1ZXJ5OwogICAgICAgIHVwZGF0ZVVSTCgpOwogICAgICB9CgogICAgICBmdW5jdGlvbiBvbkVkaXRWYXJpYWJsZXMobmV3VmFyaWFibGVzKSB7CiAgICAgICAgcGFyYW1ldGVycy52YXJpYWJsZXMgPSBuZXdWYXJpYWJsZXM7CiAgICAgICAgdXBkYXRlVVJMKCk7CiAgICAgIH0KCiAgICAgIGZ1bmN0aW9uIG9uRWRpdE9wZXJhdGlvbk5hbWUobmV3T3BlcmF0aW9uTmFtZSkgewogICAgICAgIHBhcmFtZXRlcnMub3BlcmF0aW9uTmFtZSA9IG5ld09wZXJhdGlvbk5hbWU7CiAgICAgICAgdXBkYXRlVVJMKCk7CiAgICAgIH0KCiAgICAgIGZ1bmN0aW9uIHVwZGF0ZVVSTCgpIHsKICAgICAgICB2YXIgbmV3U2VhcmNoID0gJz8nICsgT2JqZWN0LmtleXMocGFyYW1ldGVycykuZmlsdGVyKGZ1bmN0aW9uIChrZXkpIHsKICAgICAgICAgIHJldHVybiBCb29sZWFuKHBhcmFtZXRlcnNba2V5XSk7CiAgICAgICAgfSkubWFwKGZ1bmN0aW9uIChrZXkpIHsKICAgICAgICAgIHJldHVybiBlbmNvZGVVUklDb21wb25lbnQoa2V5KSArICc9JyArCiAgICAgICAgICAgIGVuY29kZVVSSUNvbXBvbmVudChwYXJhbWV0ZXJzW2tleV0pOwogICAgICAgIH0pLmpvaW4oJyYnKTsKICAgICAgICBoaXN0b3J5LnJlcGxhY2VTdGF0ZShudWxsLCBudWxsLCBuZXdTZWFyY2gpOwogICAgICB9CgogICAgICAvLyBEZWZpbmVzIGEgR3JhcGhRTCBmZXRjaGVyIHVzaW5nIHRoZSBmZXRjaCBBUEkuIFlvdSdyZSBub3QgcmVxdWlyZWQgdG8KICAgICAgLy8gdXNlIGZldGNoLCBhbmQgY291bGQgaW5zdGVhZCBpbXBsZW1lbnQgZ3JhcGhRTEZldGNoZXIgaG93ZXZlciB5b3UgbGlrZSwKICAgICAgLy8gYXMgbG9uZyBhcyBpdCByZXR1cm5zIGEgUHJvbWlzZSBvciBPYnNlcnZhYmxlLgogICAgICBmdW5jdGlvbiBncmFwaFFMRmV0Y2hlcihncmFwaFFMUGFyYW1zKSB7CiAgICAgICAgLy8gVGhpcyBleGFtcGxlIGV4cGVjdHMgYSBHcmFwaFFMIHNlcnZlciBhdCB0aGUgcGF0aCAvZ3JhcGhxbC4KICAgICAgICAvLyBDaGFuZ2UgdGhpcyB0byBwb2ludCB3aGVyZXZlciB5b3UgaG9zdCB5b3VyIEdyYXBoUUwgc2VydmVyLgogICAgICAgIC8vIHJldHVybiBmZXRjaCgnL2dyYXBocWwnLCB7CiAgICAgICAgcmV0dXJuIGZldGNoKCdodHRwOi8vJyArIGhvc3RuYW1lICsgJzo3MzUwL3YyL3JwYy9ncmFwaHFsP2h0dHBfa2V5PWRlZmF1bHRrZXknLCB7CiAgICAgICAgICBtZXRob2Q6ICdwb3N0JywKICAgICAgICAgIGhlYWRlcnM6IHsKICAgICAgICAgICAgJ0FjY2VwdCc6ICdhcHBsaWNhdGlvbi9qc29uJywKICAgICAgICAgICAgJ0NvbnRlbnQtVHlwZSc6ICdhcHBsaWNhdGlvbi9qc29uJywKICAgICAgICAgIH0sCiAgICAgICAgICBib2R5OiBKU09OLnN0cmluZ2lmeShKU09OLnN0cmluZ2lmeShncmFwaFFMUGFyYW1zKSksCiAgICAgICAgICAvLyBjcmVkZW50aWFsczogJ2luY2x1ZGUnLAogICAgICAgIH0pLnRoZW4oZnVuY3Rpb24gKHJlc3BvbnNlKSB7CiAgICAgICAgICByZXR1cm4gcmVzcG9uc2UudGV4dCgpOwogICAgICAgIH0pLnRoZW4oZnVuY3Rpb24gKHJlc3BvbnNlQm9keSkgewogICAgICAgICAgdHJ5IHsKICAgICAgICAgICAgcmV0dXJuIEpTT04ucGFyc2UoSlNPTi5wYXJzZShyZXNwb25zZUJvZHkpLnBheWxvYWQpOwogICAgICAgICAgfSBjYXRjaCAoZXJyb3IpIHsKICAgICAgICAgICAgcmV0dXJuIHJlc3BvbnNlQm9keTsKICAgICAgICAgIH0KICAgICAgICB9KTsKICAgICAgfQoKICAgICAgLy8gUmVuZGVyIDxHcmFwaGlRTCAvPiBpbnRvIHRoZSBib2R5LgogICAgICAvLyBTZWUgdGhlIFJFQURNRSBpbiB0aGUgdG9wIGxldmVsIG9mIHRoaXMgbW9kdWxlIHRvIGxlYXJuIG1vcmUgYWJvdXQKICAgICAgLy8gaG93IHlvdSBjYW4gY3VzdG9taXplIEdyYXBoaVFMIGJ5IHByb3ZpZGluZyBkaWZmZXJlbnQgdmFsdWVzIG9yCiAgICAgIC8vIGFkZGl0aW9uYWwgY2hpbGQgZWxlbWVudHMuCiAgICAgIFJlYWN0RE9NLnJlbmRlcigKICAgICAgICBSZWFjdC5jcmVhdGVFbGVtZW50KEdyYXBoaVFMLCB7CiAgICAgICAgICBmZXRjaGVyOiBncmFwaFFMRmV0Y2hlciwKICAgICAgICAgIHF1ZXJ5OiBwYXJhbWV0ZXJzLnF1ZXJ5LAogICAgICAgICAgdmFyaWFibGVzOiBwYXJhbWV0ZXJzLnZhcmlhYmxlcywKICAgICAgICAgIG9wZXJhdGlvbk5hbWU6IHBhcmFtZXRlcnMub3BlcmF0aW9uTmFtZSwKICAgICAgICAgIG9uRWRpdFF1ZXJ5OiBvbkVkaXRRdWVyeSwKICAgICAgICAgIG9uRWRpdFZhcmlhYmxlczogb25FZGl0VmFyaWFibGVzLAogICAgICAgICAgb25FZGl0T3BlcmF0aW9uTmFtZTogb25FZGl0T3BlcmF0aW9uTmFtZQogICAgICAgIH0pLAogICAgICAgIGRvY3VtZW50LmdldEVsZW1lbnRCeUlkKCdncmFwaGlxbCcpCiAgICAgICk7CiAgICA8L3NjcmlwdD4KICA8L2JvZHk+CjwvaHRtbD4K\"")
+	packr.PackJSONBytes("./static", "index.html", "\"PCFET0NUWVBFIGh0bWw+CjxodG1sPgogIDxoZWFkPgogICAgPHN0eWxlPgogICAgICBib2R5IHsKICAgICAgICBoZWlnaHQ6IDEwMCU7CiAgICAgICAgbWFyZ2luOiAwOwogICAgICAgIHdpZHRoOiAxMDAlOwogICAgICAgIG92ZXJmbG93OiBoaWRkZW47CiAgICAgIH0KICAgICAgI2dyYXBoaXFsIHsKICAgICAgICBoZWlnaHQ6IDEwMHZoOwogICAgICB9CiAgICA8L3N0eWxlPgoKICAgIDwhLS0gQnVuZGxlZCBhbmQgcGlubmVkIGluIHZlbmRvci9hc3NldHMuanNvbiwgd2hvc2UgaGFzaGVzIGBnbyBnZW5lcmF0ZSAuL3VpYCBmaWxscyBpbiBoZXJlLCB0aGUgdWkgbWFrZXMgbm8gcmVxdWVzdCB0byBhIENETiAtLT4KICAgIDxzY3JpcHQgc3JjPSJ2ZW5kb3IvZXM2LXByb21pc2UuYXV0by5taW4uanMiIGludGVncml0eT0ic2hhMzg0LUJNSEt4MC9WR2psUnRYelYrWFY4SnBPTVhHR2xuYkpIUWthaUtLMThuZkp0ZENob2xDMno2OXh3MXluQXRlSmgiPjwvc2NyaXB0PgogICAgPHNjcmlwdCBzcmM9InZlbmRvci9mZXRjaC5qcyIgaW50ZWdyaXR5PSIiPjwvc2NyaXB0PgogICAgPHNjcmlwdCBzcmM9InZlbmRvci9yZWFjdC5taW4uanMiIGludGVncml0eT0iIj48L3NjcmlwdD4KICAgIDxzY3JpcHQgc3JjPSJ2ZW5kb3IvcmVhY3QtZG9tLm1pbi5qcyIgaW50ZWdyaXR5PSIiPjwvc2NyaXB0PgoKICAgIDxsaW5rIHJlbD0ic3R5bGVzaGVldCIgaHJlZj0idmVuZG9yL2dyYXBoaXFsLmNzcyIgaW50ZWdyaXR5PSIiIC8+CiAgICA8c2NyaXB0IHNyYz0idmVuZG9yL2dyYXBoaXFsLm1pbi5qcyIgaW50ZWdyaXR5PSIiIGNoYXJzZXQ9InV0Zi04Ij48L3NjcmlwdD4KCiAgPC9oZWFkPgogIDxib2R5PgogICAgPGRpdiBpZD0iZ3JhcGhpcWwiPkxvYWRpbmcuLi48L2Rpdj4KICAgIDxzY3JpcHQ+CiAgICAgIC8vIFBhcnNlIHRoZSBzZWFyY2ggc3RyaW5nIHRvIGdldCB1cmwgcGFyYW1ldGVycy4KICAgICAgdmFyIHNlYXJjaCA9IHdpbmRvdy5sb2NhdGlvbi5zZWFyY2g7CiAgICAgIHZhciBwYXJhbWV0ZXJzID0ge307CgogICAgICBzZWFyY2guc3Vic3RyKDEpLnNwbGl0KCcmJykuZm9yRWFjaChmdW5jdGlvbiAoZW50cnkpIHsKICAgICAgICB2YXIgZXEgPSBlbnRyeS5pbmRleE9mKCc9Jyk7CiAgICAgICAgaWYgKGVxID49IDApIHsKICAgICAgICAgIHBhcmFtZXRlcnNbZGVjb2RlVVJJQ29tcG9uZW50KGVudHJ5LnNsaWNlKDAsIGVxKSldID0KICAgICAgICAgICAgZGVjb2RlVVJJQ29tcG9uZW50KGVudHJ5LnNsaWNlKGVxICsgMSkpOwogICAgICAgIH0KICAgICAgfSk7CgogICAgICAvLyBpZiB2YXJpYWJsZXMgd2FzIHByb3ZpZGVkLCB0cnkgdG8gZm9ybWF0IGl0LgogICAgICBpZiAocGFyYW1ldGVycy52YXJpYWJsZXMpIHsKICAgICAgICB0cnkgewogICAgICAgICAgcGFyYW1ldGVycy52YXJpYWJsZXMgPQogICAgICAgICAgICBKU09OLnN0cmluZ2lmeShKU09OLnBhcnNlKHBhcmFtZXRlcnMudmFyaWFibGVzKSwgbnVsbCwgMik7CiAgICAgICAgfSBjYXRjaCAoZSkgewogICAgICAgICAgLy8gRG8gbm90aGluZywgd2Ugd2FudCB0byBkaXNwbGF5IHRoZSBpbnZhbGlkIEpTT04gYXMgYSBzdHJpbmcsIHJhdGhlcgogICAgICAgICAgLy8gdGhhbiBwcmVzZW50IGFuIGVycm9yLgogICAgICAgIH0KICAgICAgfQoKICAgICAgLy8gV2hlbiB0aGUgcXVlcnkgYW5kIHZhcmlhYmxlcyBzdHJpbmcgaXMgZWRpdGVkLCB1cGRhdGUgdGhlIFVSTCBiYXIgc28KICAgICAgLy8gdGhhdCBpdCBjYW4gYmUgZWFzaWx5IHNoYXJlZAogICAgICBmdW5jdGlvbiBvbkVkaXRRdWVyeShuZXdRdWVyeSkgewogICAgICAgIHBhcmFtZXRlcnMucXVlcnkgPSBuZXdRdWVyeTsKICAgICAgICB1cGRhdGVVUkwoKTsKICAgICAgfQoKICAgICAgZnVuY3Rpb24gb25FZGl0VmFyaWFibGVzKG5ld1ZhcmlhYmxlcykgewogICAgICAgIHBhcmFtZXRlcnMudmFyaWFibGVzID0gbmV3VmFyaWFibGVzOwogICAgICAgIHVwZGF0ZVVSTCgpOwogICAgICB9CgogICAgICBmdW5jdGlvbiBvbkVkaXRPcGVyYXRpb25OYW1lKG5ld09wZXJhdGlvbk5hbWUpIHsKICAgICAgICBwYXJhbWV0ZXJzLm9wZXJhdGlvbk5hbWUgPSBuZXdPcGVyYXRpb25OYW1lOwogICAgICAgIHVwZGF0ZVVSTCgpOwogICAgICB9CgogICAgICBmdW5jdGlvbiB1cGRhdGVVUkwoKSB7CiAgICAgICAgdmFyIG5ld1NlYXJjaCA9ICc/JyArIE9iamVjdC5rZXlzKHBhcmFtZXRlcnMpLmZpbHRlcihmdW5jdGlvbiAoa2V5KSB7CiAgICAgICAgICByZXR1cm4gQm9vbGVhbihwYXJhbWV0ZXJzW2tleV0pOwogICAgICAgIH0pLm1hcChmdW5jdGlvbiAoa2V5KSB7CiAgICAgICAgICByZXR1cm4gZW5jb2RlVVJJQ29tcG9uZW50KGtleSkgKyAnPScgKwogICAgICAgICAgICBlbmNvZGVVUklDb21wb25lbnQocGFyYW1ldGVyc1trZXldKTsKICAgICAgICB9KS5qb2luKCcmJyk7CiAgICAgICAgaGlzdG9yeS5yZXBsYWNlU3RhdGUobnVsbCwgbnVsbCwgbmV3U2VhcmNoKTsKICAgICAgfQoKICAgICAgLy8gUmVxdWVzdHMgYXJlIGF1dGhlbnRpY2F0ZWQgd2l0aCB0aGUgYnJvd3NlcidzIGJhc2ljIGNyZWRlbnRpYWxzLCBvciB3aXRoCiAgICAgIC8vIGEgdG9rZW4ga2VwdCBpbiBsb2NhbCBzdG9yYWdlIGFzIGBwb3NlaWRvblRva2VuYCwgc3VjaCBhcyBhIHNlc3Npb24KICAgICAgLy8gdG9rZW4gZnJvbSAvYXV0aC9sb2dpbi4KICAgICAgZnVuY3Rpb24gYXV0aG9yaXphdGlvbigpIHsKICAgICAgICB2YXIgdG9rZW4gPSB3aW5kb3cubG9jYWxTdG9yYWdlLmdldEl0ZW0oJ3Bvc2VpZG9uVG9rZW4nKTsKICAgICAgICByZXR1cm4gdG9rZW4gPyAnQmVhcmVyICcgKyB0b2tlbiA6ICcnOwogICAgICB9CgogICAgICAvLyBEZWZpbmVzIGEgR3JhcGhRTCBmZXRjaGVyIHVzaW5nIHRoZSBmZXRjaCBBUEksIHBvc3RpbmcgdG8gdGhlIEdyYXBoUUwKICAgICAgLy8gZW5kcG9pbnQgc2VydmVkIGFsb25nIHdpdGggdGhpcyBwYWdlLgogICAgICBmdW5jdGlvbiBncmFwaFFMRmV0Y2hlcihncmFwaFFMUGFyYW1zKSB7CiAgICAgICAgdmFyIGhlYWRlcnMgPSB7CiAgICAgICAgICAnQWNjZXB0JzogJ2FwcGxpY2F0aW9uL2pzb24nLAogICAgICAgICAgJ0NvbnRlbnQtVHlwZSc6ICdhcHBsaWNhdGlvbi9qc29uJywKICAgICAgICB9OwogICAgICAgIGlmIChhdXRob3JpemF0aW9uKCkpIHsKICAgICAgICAgIGhlYWRlcnNbJ0F1dGhvcml6YXRpb24nXSA9IGF1dGhvcml6YXRpb24oKTsKICAgICAgICB9CiAgICAgICAgcmV0dXJuIGZldGNoKCcvZ3JhcGhxbCcsIHsKICAgICAgICAgIG1ldGhvZDogJ3Bvc3QnLAogICAgICAgICAgaGVhZGVyczogaGVhZGVycywKICAgICAgICAgIGJvZHk6IEpTT04uc3RyaW5naWZ5KGdyYXBoUUxQYXJhbXMpLAogICAgICAgICAgY3JlZGVudGlhbHM6ICdpbmNsdWRlJywKICAgICAgICB9KS50aGVuKGZ1bmN0aW9uIChyZXNwb25zZSkgewogICAgICAgICAgcmV0dXJuIHJlc3BvbnNlLnRleHQoKTsKICAgICAgICB9KS50aGVuKGZ1bmN0aW9uIChyZXNwb25zZUJvZHkpIHsKICAgICAgICAgIHRyeSB7CiAgICAgICAgICAgIHJldHVybiBKU09OLnBhcnNlKHJlc3BvbnNlQm9keSk7CiAgICAgICAgICB9IGNhdGNoIChlcnJvcikgewogICAgICAgICAgICByZXR1cm4gcmVzcG9uc2VCb2R5OwogICAgICAgICAgfQogICAgICAgIH0pOwogICAgICB9CgogICAgICAvLyBTdWJzY3JpcHRpb25zIGFyZSBleGVjdXRlZCBvdmVyIHRoZSB3ZWJzb2NrZXQgb2YgdGhpcyBzZXJ2ZXIsIHNwZWFraW5nCiAgICAgIC8vIHRoZSBncmFwaHFsLXdzIHByb3RvY29sLiBUaGUgZmV0Y2hlciByZXR1cm5zIGFuIG9ic2VydmFibGUgZm9yIHRoZW0sCiAgICAgIC8vIHdoaWNoIEdyYXBoaVFMIGtlZXBzIHJlbmRlcmluZyB0aGUgbGF0ZXN0IHJlc3VsdCBvZi4KICAgICAgdmFyIHNvY2tldCA9IG51bGw7CiAgICAgIHZhciBzb2NrZXRSZWFkeSA9IG51bGw7CiAgICAgIHZhciBvcGVyYXRpb25zID0ge307CiAgICAgIHZhciBuZXh0T3BlcmF0aW9uSWQgPSAxOwoKICAgICAgZnVuY3Rpb24gb3BlblNvY2tldCgpIHsKICAgICAgICBpZiAoc29ja2V0UmVhZHkpIHsKICAgICAgICAgIHJldHVybiBzb2NrZXRSZWFkeTsKICAgICAgICB9CiAgICAgICAgdmFyIHByb3RvY29sID0gd2luZG93LmxvY2F0aW9uLnByb3RvY29sID09PSAnaHR0cHM6JyA/ICd3c3M6Ly8nIDogJ3dzOi8vJzsKICAgICAgICBzb2NrZXQgPSBuZXcgV2ViU29ja2V0KHByb3RvY29sICsgd2luZG93LmxvY2F0aW9uLmhvc3QgKyAnL2dyYXBocWwnLCAnZ3JhcGhxbC13cycpOwogICAgICAgIHNvY2tldFJlYWR5ID0gbmV3IFByb21pc2UoZnVuY3Rpb24gKHJlc29sdmUsIHJlamVjdCkgewogICAgICAgICAgc29ja2V0Lm9ub3BlbiA9IGZ1bmN0aW9uICgpIHsKICAgICAgICAgICAgc29ja2V0LnNlbmQoSlNPTi5zdHJpbmdpZnkoe3R5cGU6ICdjb25uZWN0aW9uX2luaXQnLCBwYXlsb2FkOiB7YXV0aG9yaXphdGlvbjogYXV0aG9yaXphdGlvbigpfX0pKTsKICAgICAgICAgIH07CiAgICAgICAgICBzb2NrZXQub25tZXNzYWdlID0gZnVuY3Rpb24gKGV2ZW50KSB7CiAgICAgICAgICAgIHZhciBtZXNzYWdlID0gSlNPTi5wYXJzZShldmVudC5kYXRhKTsKICAgICAgICAgICAgdmFyIG9ic2VydmVyID0gb3BlcmF0aW9uc1ttZXNzYWdlLmlkXTsKICAgICAgICAgICAgc3dpdGNoIChtZXNzYWdlLnR5cGUpIHsKICAgICAgICAgICAgICBjYXNlICdjb25uZWN0aW9uX2Fjayc6CiAgICAgICAgICAgICAgICByZXNvbHZlKHNvY2tldCk7CiAgICAgICAgICAgICAgICBicmVhazsKICAgICAgICAgICAgICBjYXNlICdjb25uZWN0aW9uX2Vycm9yJzoKICAgICAgICAgICAgICAgIHJlamVjdChuZXcgRXJyb3IobWVzc2FnZS5wYXlsb2FkLm1lc3NhZ2UpKTsKICAgICAgICAgICAgICAgIGJyZWFrOwogICAgICAgICAgICAgIGNhc2UgJ2RhdGEnOgogICAgICAgICAgICAgICAgb2JzZXJ2ZXIgJiYgb2JzZXJ2ZXIubmV4dChtZXNzYWdlLnBheWxvYWQpOwogICAgICAgICAgICAgICAgYnJlYWs7CiAgICAgICAgICAgICAgY2FzZSAnZXJyb3InOgogICAgICAgICAgICAgICAgb2JzZXJ2ZXIgJiYgb2JzZXJ2ZXIubmV4dCh7ZXJyb3JzOiBbbWVzc2FnZS5wYXlsb2FkXX0pOwogICAgICAgICAgICAgICAgYnJlYWs7CiAgICAgICAgICAgICAgY2FzZSAnY29tcGxldGUnOgogICAgICAgICAgICAgICAgZGVsZXRlIG9wZXJhdGlvbnNbbWVzc2FnZS5pZF07CiAgICAgICAgICAgICAgICBvYnNlcnZlciAmJiBvYnNlcnZlci5jb21wbGV0ZSAmJiBvYnNlcnZlci5jb21wbGV0ZSgpOwogICAgICAgICAgICAgICAgYnJlYWs7CiAgICAgICAgICAgIH0KICAgICAgICAgIH07CiAgICAgICAgICBzb2NrZXQub25jbG9zZSA9IGZ1bmN0aW9uICgpIHsKICAgICAgICAgICAgT2JqZWN0LmtleXMob3BlcmF0aW9ucykuZm9yRWFjaChmdW5jdGlvbiAoaWQpIHsKICAgICAgICAgICAgICBvcGVyYXRpb25zW2lkXS5uZXh0KHtlcnJvcnM6IFt7bWVzc2FnZTogJ3RoZSBzdWJzY3JpcHRpb24gd2Vic29ja2V0IHdhcyBjbG9zZWQnfV19KTsKICAgICAgICAgICAgfSk7CiAgICAgICAgICAgIG9wZXJhdGlvbnMgPSB7fTsKICAgICAgICAgICAgc29ja2V0ID0gc29ja2V0UmVhZHkgPSBudWxsOwogICAgICAgICAgICByZWplY3QobmV3IEVycm9yKCd0aGUgc3Vic2NyaXB0aW9uIHdlYnNvY2tldCB3YXMgY2xvc2VkJykpOwogICAgICAgICAgfTsKICAgICAgICB9KTsKICAgICAgICByZXR1cm4gc29ja2V0UmVhZHk7CiAgICAgIH0KCiAgICAgIGZ1bmN0aW9uIGlzU3Vic2NyaXB0aW9uKGdyYXBoUUxQYXJhbXMpIHsKICAgICAgICB2YXIgcXVlcnkgPSBncmFwaFFMUGFyYW1zLnF1ZXJ5LnJlcGxhY2UoLyMuKi9nLCAnJyk7CiAgICAgICAgdmFyIG5hbWUgPSBncmFwaFFMUGFyYW1zLm9wZXJhdGlvbk5hbWU7CiAgICAgICAgdmFyIHBhdHRlcm4gPSBuYW1lID8KICAgICAgICAgIG5ldyBSZWdFeHAoJ1xcYnN1YnNjcmlwdGlvblxccysnICsgbmFtZSArICdcXGInKSA6CiAgICAgICAgICAvXlxzKnN1YnNjcmlwdGlvblxiLzsKICAgICAgICByZXR1cm4gcGF0dGVybi50ZXN0KHF1ZXJ5KTsKICAgICAgfQoKICAgICAgZnVuY3Rpb24gc3Vic2NyaWJlKGdyYXBoUUxQYXJhbXMpIHsKICAgICAgICByZXR1cm4gewogICAgICAgICAgc3Vic2NyaWJlOiBmdW5jdGlvbiAob2JzZXJ2ZXIpIHsKICAgICAgICAgICAgdmFyIGlkID0gU3RyaW5nKG5leHRPcGVyYXRpb25JZCsrKTsKICAgICAgICAgICAgdmFyIHN0b3BwZWQgPSBmYWxzZTsKICAgICAgICAgICAgb3BlblNvY2tldCgpLnRoZW4oZnVuY3Rpb24gKHNvY2tldCkgewogICAgICAgICAgICAgIGlmIChzdG9wcGVkKSB7CiAgICAgICAgICAgICAgICByZXR1cm47CiAgICAgICAgICAgICAgfQogICAgICAgICAgICAgIG9wZXJhdGlvbnNbaWRdID0gb2JzZXJ2ZXI7CiAgICAgICAgICAgICAgc29ja2V0LnNlbmQoSlNPTi5zdHJpbmdpZnkoe2lkOiBpZCwgdHlwZTogJ3N0YXJ0JywgcGF5bG9hZDogZ3JhcGhRTFBhcmFtc30pKTsKICAgICAgICAgICAgfSwgZnVuY3Rpb24gKGVycm9yKSB7CiAgICAgICAgICAgICAgb2JzZXJ2ZXIubmV4dCh7ZXJyb3JzOiBbe21lc3NhZ2U6IGVycm9yLm1lc3NhZ2V9XX0pOwogICAgICAgICAgICB9KTsKICAgICAgICAgICAgcmV0dXJuIHsKICAgICAgICAgICAgICB1bnN1YnNjcmliZTogZnVuY3Rpb24gKCkgewogICAgICAgICAgICAgICAgc3RvcHBlZCA9IHRydWU7CiAgICAgICAgICAgICAgICBpZiAob3BlcmF0aW9uc1tpZF0pIHsKICAgICAgICAgICAgICAgICAgZGVsZXRlIG9wZXJhdGlvbnNbaWRdOwogICAgICAgICAgICAgICAgICBzb2NrZXQuc2VuZChKU09OLnN0cmluZ2lmeSh7aWQ6IGlkLCB0eXBlOiAnc3RvcCd9KSk7CiAgICAgICAgICAgICAgICB9CiAgICAgICAgICAgICAgfQogICAgICAgICAgICB9OwogICAgICAgICAgfQogICAgICAgIH07CiAgICAgIH0KCiAgICAgIGZ1bmN0aW9uIGZldGNoZXIoZ3JhcGhRTFBhcmFtcykgewogICAgICAgIGlmIChpc1N1YnNjcmlwdGlvbihncmFwaFFMUGFyYW1zKSkgewogICAgICAgICAgcmV0dXJuIHN1YnNjcmliZShncmFwaFFMUGFyYW1zKTsKICAgICAgICB9CiAgICAgICAgcmV0dXJuIGdyYXBoUUxGZXRjaGVyKGdyYXBoUUxQYXJhbXMpOwogICAgICB9CgogICAgICAvLyBSZW5kZXIgPEdyYXBoaVFMIC8+IGludG8gdGhlIGJvZHkuCiAgICAgIC8vIFNlZSB0aGUgUkVBRE1FIGluIHRoZSB0b3AgbGV2ZWwgb2YgdGhpcyBtb2R1bGUgdG8gbGVhcm4gbW9yZSBhYm91dAogICAgICAvLyBob3cgeW91IGNhbiBjdXN0b21pemUgR3JhcGhpUUwgYnkgcHJvdmlkaW5nIGRpZmZlcmVudCB2YWx1ZXMgb3IKICAgICAgLy8gYWRkaXRpb25hbCBjaGlsZCBlbGVtZW50cy4KICAgICAgUmVhY3RET00ucmVuZGVyKAogICAgICAgIFJlYWN0LmNyZWF0ZUVsZW1lbnQoR3JhcGhpUUwsIHsKICAgICAgICAgIGZldGNoZXI6IGZldGNoZXIsCiAgICAgICAgICBxdWVyeTogcGFyYW1ldGVycy5xdWVyeSwKICAgICAgICAgIHZhcmlhYmxlczogcGFyYW1ldGVycy52YXJpYWJsZXMsCiAgICAgICAgICBvcGVyYXRpb25OYW1lOiBwYXJhbWV0ZXJzLm9wZXJhdGlvbk5hbWUsCiAgICAgICAgICBvbkVkaXRRdWVyeTogb25FZGl0UXVlcnksCiAgICAgICAgICBvbkVkaXRWYXJpYWJsZXM6IG9uRWRpdFZhcmlhYmxlcywKICAgICAgICAgIG9uRWRpdE9wZXJhdGlvbk5hbWU6IG9uRWRpdE9wZXJhdGlvbk5hbWUKICAgICAgICB9KSwKICAgICAgICBkb2N1bWVudC5nZXRFbGVtZW50QnlJZCgnZ3JhcGhpcWwnKQogICAgICApOwogICAgPC9zY3JpcHQ+CiAgPC9ib2R5Pgo8L2h0bWw+Cg==\"")
+	packr.PackJSONBytes("./static", "vendor/assets.json", "\"WwogIHsKICAgICJuYW1lIjogImVzNi1wcm9taXNlIiwKICAgICJ2ZXJzaW9uIjogIjQuMi44IiwKICAgICJ1cmwiOiAiaHR0cHM6Ly9jZG4uanNkZWxpdnIubmV0L25wbS9lczYtcHJvbWlzZUA0LjIuOC9kaXN0L2VzNi1wcm9taXNlLmF1dG8ubWluLmpzIiwKICAgICJwYXRoIjogImVzNi1wcm9taXNlLmF1dG8ubWluLmpzIiwKICAgICJpbnRlZ3JpdHkiOiAic2hhMzg0LUJNSEt4MC9WR2psUnRYelYrWFY4SnBPTVhHR2xuYkpIUWthaUtLMThuZkp0ZENob2xDMno2OXh3MXluQXRlSmgiCiAgfSwKICB7CiAgICAibmFtZSI6ICJ3aGF0d2ctZmV0Y2giLAogICAgInZlcnNpb24iOiAiMC45LjAiLAogICAgInVybCI6ICJodHRwczovL2Nkbi5qc2RlbGl2ci5uZXQvbnBtL3doYXR3Zy1mZXRjaEAwLjkuMC9mZXRjaC5qcyIsCiAgICAicGF0aCI6ICJmZXRjaC5qcyIsCiAgICAiaW50ZWdyaXR5IjogIiIKICB9LAogIHsKICAgICJuYW1lIjogInJlYWN0IiwKICAgICJ2ZXJzaW9uIjogIjE1LjQuMiIsCiAgICAidXJsIjogImh0dHBzOi8vY2RuLmpzZGVsaXZyLm5ldC9ucG0vcmVhY3RAMTUuNC4yL2Rpc3QvcmVhY3QubWluLmpzIiwKICAgICJwYXRoIjogInJlYWN0Lm1pbi5qcyIsCiAgICAiaW50ZWdyaXR5IjogIiIKICB9LAogIHsKICAgICJuYW1lIjogInJlYWN0LWRvbSIsCiAgICAidmVyc2lvbiI6ICIxNS40LjIiLAogICAgInVybCI6ICJodHRwczovL2Nkbi5qc2RlbGl2ci5uZXQvbnBtL3JlYWN0LWRvbUAxNS40LjIvZGlzdC9yZWFjdC1kb20ubWluLmpzIiwKICAgICJwYXRoIjogInJlYWN0LWRvbS5taW4uanMiLAogICAgImludGVncml0eSI6ICIiCiAgfSwKICB7CiAgICAibmFtZSI6ICJncmFwaGlxbCIsCiAgICAidmVyc2lvbiI6ICIwLjEyLjAiLAogICAgInVybCI6ICJodHRwczovL2Nkbi5qc2RlbGl2ci5uZXQvbnBtL2dyYXBoaXFsQDAuMTIuMC9ncmFwaGlxbC5taW4uanMiLAogICAgInBhdGgiOiAiZ3JhcGhpcWwubWluLmpzIiwKICAgICJpbnRlZ3JpdHkiOiAiIgogIH0sCiAgewogICAgIm5hbWUiOiAiZ3JhcGhpcWwiLAogICAgInZlcnNpb24iOiAiMC4xMi4wIiwKICAgICJ1cmwiOiAiaHR0cHM6Ly9jZG4uanNkZWxpdnIubmV0L25wbS9ncmFwaGlxbEAwLjEyLjAvZ3JhcGhpcWwuY3NzIiwKICAgICJwYXRoIjogImdyYXBoaXFsLmNzcyIsCiAgICAiaW50ZWdyaXR5IjogIiIKICB9Cl0K\"")
+	packr.PackJSONBytes("./static", "vendor/es6-promise.auto.min.js", "\"IWZ1bmN0aW9uKHQsZSl7Im9iamVjdCI9PXR5cGVvZiBleHBvcnRzJiYidW5kZWZpbmVkIiE9dHlwZW9mIG1vZHVsZT9tb2R1bGUuZXhwb3J0cz1lKCk6ImZ1bmN0aW9uIj09dHlwZW9mIGRlZmluZSYmZGVmaW5lLmFtZD9kZWZpbmUoZSk6dC5FUzZQcm9taXNlPWUoKX0odGhpcyxmdW5jdGlvbigpeyJ1c2Ugc3RyaWN0IjtmdW5jdGlvbiB0KHQpe3ZhciBlPXR5cGVvZiB0O3JldHVybiBudWxsIT09dCYmKCJvYmplY3QiPT09ZXx8ImZ1bmN0aW9uIj09PWUpfWZ1bmN0aW9uIGUodCl7cmV0dXJuImZ1bmN0aW9uIj09dHlwZW9mIHR9ZnVuY3Rpb24gbih0KXtXPXR9ZnVuY3Rpb24gcih0KXt6PXR9ZnVuY3Rpb24gbygpe3JldHVybiBmdW5jdGlvbigpe3JldHVybiBwcm9jZXNzLm5leHRUaWNrKGEpfX1mdW5jdGlvbiBpKCl7cmV0dXJuInVuZGVmaW5lZCIhPXR5cGVvZiBVP2Z1bmN0aW9uKCl7VShhKX06YygpfWZ1bmN0aW9uIHMoKXt2YXIgdD0wLGU9bmV3IEgoYSksbj1kb2N1bWVudC5jcmVhdGVUZXh0Tm9kZSgiIik7cmV0dXJuIGUub2JzZXJ2ZShuLHtjaGFyYWN0ZXJEYXRhOiEwfSksZnVuY3Rpb24oKXtuLmRhdGE9dD0rK3QlMn19ZnVuY3Rpb24gdSgpe3ZhciB0PW5ldyBNZXNzYWdlQ2hhbm5lbDtyZXR1cm4gdC5wb3J0MS5vbm1lc3NhZ2U9YSxmdW5jdGlvbigpe3JldHVybiB0LnBvcnQyLnBvc3RNZXNzYWdlKDApfX1mdW5jdGlvbiBjKCl7dmFyIHQ9c2V0VGltZW91dDtyZXR1cm4gZnVuY3Rpb24oKXtyZXR1cm4gdChhLDEpfX1mdW5jdGlvbiBhKCl7Zm9yKHZhciB0PTA7dDxOO3QrPTIpe3ZhciBlPVFbdF0sbj1RW3QrMV07ZShuKSxRW3RdPXZvaWQgMCxRW3QrMV09dm9pZCAwfU49MH1mdW5jdGlvbiBmKCl7dHJ5e3ZhciB0PUZ1bmN0aW9uKCJyZXR1cm4gdGhpcyIpKCkucmVxdWlyZSgidmVydHgiKTtyZXR1cm4gVT10LnJ1bk9uTG9vcHx8dC5ydW5PbkNvbnRleHQsaSgpfWNhdGNoKGUpe3JldHVybiBjKCl9fWZ1bmN0aW9uIGwodCxlKXt2YXIgbj10aGlzLHI9bmV3IHRoaXMuY29uc3RydWN0b3IocCk7dm9pZCAwPT09cltWXSYmeChyKTt2YXIgbz1uLl9zdGF0ZTtpZihvKXt2YXIgaT1hcmd1bWVudHNbby0xXTt6KGZ1bmN0aW9uKCl7cmV0dXJuIFQobyxyLGksbi5fcmVzdWx0KX0pfWVsc2UgaihuLHIsdCxlKTtyZXR1cm4gcn1mdW5jdGlvbiBoKHQpe3ZhciBlPXRoaXM7aWYodCYmIm9iamVjdCI9PXR5cGVvZiB0JiZ0LmNvbnN0cnVjdG9yPT09ZSlyZXR1cm4gdDt2YXIgbj1uZXcgZShwKTtyZXR1cm4gdyhuLHQpLG59ZnVuY3Rpb24gcCgpe31mdW5jdGlvbiB2KCl7cmV0dXJuIG5ldyBUeXBlRXJyb3IoIllvdSBjYW5ub3QgcmVzb2x2ZSBhIHByb21pc2Ugd2l0aCBpdHNlbGYiKX1mdW5jdGlvbiBkKCl7cmV0dXJuIG5ldyBUeXBlRXJyb3IoIkEgcHJvbWlzZXMgY2FsbGJhY2sgY2Fubm90IHJldHVybiB0aGF0IHNhbWUgcHJvbWlzZS4iKX1mdW5jdGlvbiBfKHQsZSxuLHIpe3RyeXt0LmNhbGwoZSxuLHIpfWNhdGNoKG8pe3JldHVybiBvfX1mdW5jdGlvbiB5KHQsZSxuKXt6KGZ1bmN0aW9uKHQpe3ZhciByPSExLG89XyhuLGUsZnVuY3Rpb24obil7cnx8KHI9ITAsZSE9PW4/dyh0LG4pOkEodCxuKSl9LGZ1bmN0aW9uKGUpe3J8fChyPSEwLFModCxlKSl9LCJTZXR0bGU6ICIrKHQuX2xhYmVsfHwiIHVua25vd24gcHJvbWlzZSIpKTshciYmbyYmKHI9ITAsUyh0LG8pKX0sdCl9ZnVuY3Rpb24gbSh0LGUpe2UuX3N0YXRlPT09Wj9BKHQsZS5fcmVzdWx0KTplLl9zdGF0ZT09PSQ/Uyh0LGUuX3Jlc3VsdCk6aihlLHZvaWQgMCxmdW5jdGlvbihlKXtyZXR1cm4gdyh0LGUpfSxmdW5jdGlvbihlKXtyZXR1cm4gUyh0LGUpfSl9ZnVuY3Rpb24gYih0LG4scil7bi5jb25zdHJ1Y3Rvcj09PXQuY29uc3RydWN0b3ImJnI9PT1sJiZuLmNvbnN0cnVjdG9yLnJlc29sdmU9PT1oP20odCxuKTp2b2lkIDA9PT1yP0EodCxuKTplKHIpP3kodCxuLHIpOkEodCxuKX1mdW5jdGlvbiB3KGUsbil7aWYoZT09PW4pUyhlLHYoKSk7ZWxzZSBpZih0KG4pKXt2YXIgcj12b2lkIDA7dHJ5e3I9bi50aGVufWNhdGNoKG8pe3JldHVybiB2b2lkIFMoZSxvKX1iKGUsbixyKX1lbHNlIEEoZSxuKX1mdW5jdGlvbiBnKHQpe3QuX29uZXJyb3ImJnQuX29uZXJyb3IodC5fcmVzdWx0KSxFKHQpfWZ1bmN0aW9uIEEodCxlKXt0Ll9zdGF0ZT09PVgmJih0Ll9yZXN1bHQ9ZSx0Ll9zdGF0ZT1aLDAhPT10Ll9zdWJzY3JpYmVycy5sZW5ndGgmJnooRSx0KSl9ZnVuY3Rpb24gUyh0LGUpe3QuX3N0YXRlPT09WCYmKHQuX3N0YXRlPSQsdC5fcmVzdWx0PWUseihnLHQpKX1mdW5jdGlvbiBqKHQsZSxuLHIpe3ZhciBvPXQuX3N1YnNjcmliZXJzLGk9by5sZW5ndGg7dC5fb25lcnJvcj1udWxsLG9baV09ZSxvW2krWl09bixvW2krJF09ciwwPT09aSYmdC5fc3RhdGUmJnooRSx0KX1mdW5jdGlvbiBFKHQpe3ZhciBlPXQuX3N1YnNjcmliZXJzLG49dC5fc3RhdGU7aWYoMCE9PWUubGVuZ3RoKXtmb3IodmFyIHI9dm9pZCAwLG89dm9pZCAwLGk9dC5fcmVzdWx0LHM9MDtzPGUubGVuZ3RoO3MrPTMpcj1lW3NdLG89ZVtzK25dLHI/VChuLHIsbyxpKTpvKGkpO3QuX3N1YnNjcmliZXJzLmxlbmd0aD0wfX1mdW5jdGlvbiBUKHQsbixyLG8pe3ZhciBpPWUocikscz12b2lkIDAsdT12b2lkIDAsYz0hMDtpZihpKXt0cnl7cz1yKG8pfWNhdGNoKGEpe2M9ITEsdT1hfWlmKG49PT1zKXJldHVybiB2b2lkIFMobixkKCkpfWVsc2Ugcz1vO24uX3N0YXRlIT09WHx8KGkmJmM/dyhuLHMpOmM9PT0hMT9TKG4sdSk6dD09PVo/QShuLHMpOnQ9PT0kJiZTKG4scykpfWZ1bmN0aW9uIE0odCxlKXt0cnl7ZShmdW5jdGlvbihlKXt3KHQsZSl9LGZ1bmN0aW9uKGUpe1ModCxlKX0pfWNhdGNoKG4pe1ModCxuKX19ZnVuY3Rpb24gUCgpe3JldHVybiB0dCsrfWZ1bmN0aW9uIHgodCl7dFtWXT10dCsrLHQuX3N0YXRlPXZvaWQgMCx0Ll9yZXN1bHQ9dm9pZCAwLHQuX3N1YnNjcmliZXJzPVtdfWZ1bmN0aW9uIEMoKXtyZXR1cm4gbmV3IEVycm9yKCJBcnJheSBNZXRob2RzIG11c3QgYmUgcHJvdmlkZWQgYW4gQXJyYXkiKX1mdW5jdGlvbiBPKHQpe3JldHVybiBuZXcgZXQodGhpcyx0KS5wcm9taXNlfWZ1bmN0aW9uIGsodCl7dmFyIGU9dGhpcztyZXR1cm4gbmV3IGUoTCh0KT9mdW5jdGlvbihuLHIpe2Zvcih2YXIgbz10Lmxlbmd0aCxpPTA7aTxvO2krKyllLnJlc29sdmUodFtpXSkudGhlbihuLHIpfTpmdW5jdGlvbih0LGUpe3JldHVybiBlKG5ldyBUeXBlRXJyb3IoIllvdSBtdXN0IHBhc3MgYW4gYXJyYXkgdG8gcmFjZS4iKSl9KX1mdW5jdGlvbiBGKHQpe3ZhciBlPXRoaXMsbj1uZXcgZShwKTtyZXR1cm4gUyhuLHQpLG59ZnVuY3Rpb24gWSgpe3Rocm93IG5ldyBUeXBlRXJyb3IoIllvdSBtdXN0IHBhc3MgYSByZXNvbHZlciBmdW5jdGlvbiBhcyB0aGUgZmlyc3QgYXJndW1lbnQgdG8gdGhlIHByb21pc2UgY29uc3RydWN0b3IiKX1mdW5jdGlvbiBxKCl7dGhyb3cgbmV3IFR5cGVFcnJvcigiRmFpbGVkIHRvIGNvbnN0cnVjdCAnUHJvbWlzZSc6IFBsZWFzZSB1c2UgdGhlICduZXcnIG9wZXJhdG9yLCB0aGlzIG9iamVjdCBjb25zdHJ1Y3RvciBjYW5ub3QgYmUgY2FsbGVkIGFzIGEgZnVuY3Rpb24uIil9ZnVuY3Rpb24gRCgpe3ZhciB0PXZvaWQgMDtpZigidW5kZWZpbmVkIiE9dHlwZW9mIGdsb2JhbCl0PWdsb2JhbDtlbHNlIGlmKCJ1bmRlZmluZWQiIT10eXBlb2Ygc2VsZil0PXNlbGY7ZWxzZSB0cnl7dD1GdW5jdGlvbigicmV0dXJuIHRoaXMiKSgpfWNhdGNoKGUpe3Rocm93IG5ldyBFcnJvcigicG9seWZpbGwgZmFpbGVkIGJlY2F1c2UgZ2xvYmFsIG9iamVjdCBpcyB1bmF2YWlsYWJsZSBpbiB0aGlzIGVudmlyb25tZW50Iil9dmFyIG49dC5Qcm9taXNlO2lmKG4pe3ZhciByPW51bGw7dHJ5e3I9T2JqZWN0LnByb3RvdHlwZS50b1N0cmluZy5jYWxsKG4ucmVzb2x2ZSgpKX1jYXRjaChlKXt9aWYoIltvYmplY3QgUHJvbWlzZV0iPT09ciYmIW4uY2FzdClyZXR1cm59dC5Qcm9taXNlPW50fXZhciBLPXZvaWQgMDtLPUFycmF5LmlzQXJyYXk/QXJyYXkuaXNBcnJheTpmdW5jdGlvbih0KXtyZXR1cm4iW29iamVjdCBBcnJheV0iPT09T2JqZWN0LnByb3RvdHlwZS50b1N0cmluZy5jYWxsKHQpfTt2YXIgTD1LLE49MCxVPXZvaWQgMCxXPXZvaWQgMCx6PWZ1bmN0aW9uKHQsZSl7UVtOXT10LFFbTisxXT1lLE4rPTIsMj09PU4mJihXP1coYSk6UigpKX0sQj0idW5kZWZpbmVkIiE9dHlwZW9mIHdpbmRvdz93aW5kb3c6dm9pZCAwLEc9Qnx8e30sSD1HLk11dGF0aW9uT2JzZXJ2ZXJ8fEcuV2ViS2l0TXV0YXRpb25PYnNlcnZlcixJPSJ1bmRlZmluZWQiPT10eXBlb2Ygc2VsZiYmInVuZGVmaW5lZCIhPXR5cGVvZiBwcm9jZXNzJiYiW29iamVjdCBwcm9jZXNzXSI9PT17fS50b1N0cmluZy5jYWxsKHByb2Nlc3MpLEo9InVuZGVmaW5lZCIhPXR5cGVvZiBVaW50OENsYW1wZWRBcnJheSYmInVuZGVmaW5lZCIhPXR5cGVvZiBpbXBvcnRTY3JpcHRzJiYidW5kZWZpbmVkIiE9dHlwZW9mIE1lc3NhZ2VDaGFubmVsLFE9bmV3IEFycmF5KDFlMyksUj12b2lkIDA7Uj1JP28oKTpIP3MoKTpKP3UoKTp2b2lkIDA9PT1CJiYiZnVuY3Rpb24iPT10eXBlb2YgcmVxdWlyZT9mKCk6YygpO3ZhciBWPU1hdGgucmFuZG9tKCkudG9TdHJpbmcoMzYpLnN1YnN0cmluZygyKSxYPXZvaWQgMCxaPTEsJD0yLHR0PTAsZXQ9ZnVuY3Rpb24oKXtmdW5jdGlvbiB0KHQsZSl7dGhpcy5faW5zdGFuY2VDb25zdHJ1Y3Rvcj10LHRoaXMucHJvbWlzZT1uZXcgdChwKSx0aGlzLnByb21pc2VbVl18fHgodGhpcy5wcm9taXNlKSxMKGUpPyh0aGlzLmxlbmd0aD1lLmxlbmd0aCx0aGlzLl9yZW1haW5pbmc9ZS5sZW5ndGgsdGhpcy5fcmVzdWx0PW5ldyBBcnJheSh0aGlzLmxlbmd0aCksMD09PXRoaXMubGVuZ3RoP0EodGhpcy5wcm9taXNlLHRoaXMuX3Jlc3VsdCk6KHRoaXMubGVuZ3RoPXRoaXMubGVuZ3RofHwwLHRoaXMuX2VudW1lcmF0ZShlKSwwPT09dGhpcy5fcmVtYWluaW5nJiZBKHRoaXMucHJvbWlzZSx0aGlzLl9yZXN1bHQpKSk6Uyh0aGlzLnByb21pc2UsQygpKX1yZXR1cm4gdC5wcm90b3R5cGUuX2VudW1lcmF0ZT1mdW5jdGlvbih0KXtmb3IodmFyIGU9MDt0aGlzLl9zdGF0ZT09PVgmJmU8dC5sZW5ndGg7ZSsrKXRoaXMuX2VhY2hFbnRyeSh0W2VdLGUpfSx0LnByb3RvdHlwZS5fZWFjaEVudHJ5PWZ1bmN0aW9uKHQsZSl7dmFyIG49dGhpcy5faW5zdGFuY2VDb25zdHJ1Y3RvcixyPW4ucmVzb2x2ZTtpZihyPT09aCl7dmFyIG89dm9pZCAwLGk9dm9pZCAwLHM9ITE7dHJ5e289dC50aGVufWNhdGNoKHUpe3M9ITAsaT11fWlmKG89PT1sJiZ0Ll9zdGF0ZSE9PVgpdGhpcy5fc2V0dGxlZEF0KHQuX3N0YXRlLGUsdC5fcmVzdWx0KTtlbHNlIGlmKCJmdW5jdGlvbiIhPXR5cGVvZiBvKXRoaXMuX3JlbWFpbmluZy0tLHRoaXMuX3Jlc3VsdFtlXT10O2Vsc2UgaWYobj09PW50KXt2YXIgYz1uZXcgbihwKTtzP1MoYyxpKTpiKGMsdCxvKSx0aGlzLl93aWxsU2V0dGxlQXQoYyxlKX1lbHNlIHRoaXMuX3dpbGxTZXR0bGVBdChuZXcgbihmdW5jdGlvbihlKXtyZXR1cm4gZSh0KX0pLGUpfWVsc2UgdGhpcy5fd2lsbFNldHRsZUF0KHIodCksZSl9LHQucHJvdG90eXBlLl9zZXR0bGVkQXQ9ZnVuY3Rpb24odCxlLG4pe3ZhciByPXRoaXMucHJvbWlzZTtyLl9zdGF0ZT09PVgmJih0aGlzLl9yZW1haW5pbmctLSx0PT09JD9TKHIsbik6dGhpcy5fcmVzdWx0W2VdPW4pLDA9PT10aGlzLl9yZW1haW5pbmcmJkEocix0aGlzLl9yZXN1bHQpfSx0LnByb3RvdHlwZS5fd2lsbFNldHRsZUF0PWZ1bmN0aW9uKHQsZSl7dmFyIG49dGhpcztqKHQsdm9pZCAwLGZ1bmN0aW9uKHQpe3JldHVybiBuLl9zZXR0bGVkQXQoWixlLHQpfSxmdW5jdGlvbih0KXtyZXR1cm4gbi5fc2V0dGxlZEF0KCQsZSx0KX0pfSx0fSgpLG50PWZ1bmN0aW9uKCl7ZnVuY3Rpb24gdChlKXt0aGlzW1ZdPVAoKSx0aGlzLl9yZXN1bHQ9dGhpcy5fc3RhdGU9dm9pZCAwLHRoaXMuX3N1YnNjcmliZXJzPVtdLHAhPT1lJiYoImZ1bmN0aW9uIiE9dHlwZW9mIGUmJlkoKSx0aGlzIGluc3RhbmNlb2YgdD9NKHRoaXMsZSk6cSgpKX1yZXR1cm4gdC5wcm90b3R5cGVbImNhdGNoIl09ZnVuY3Rpb24odCl7cmV0dXJuIHRoaXMudGhlbihudWxsLHQpfSx0LnByb3RvdHlwZVsiZmluYWxseSJdPWZ1bmN0aW9uKHQpe3ZhciBuPXRoaXMscj1uLmNvbnN0cnVjdG9yO3JldHVybiBlKHQpP24udGhlbihmdW5jdGlvbihlKXtyZXR1cm4gci5yZXNvbHZlKHQoKSkudGhlbihmdW5jdGlvbigpe3JldHVybiBlfSl9LGZ1bmN0aW9uKGUpe3JldHVybiByLnJlc29sdmUodCgpKS50aGVuKGZ1bmN0aW9uKCl7dGhyb3cgZX0pfSk6bi50aGVuKHQsdCl9LHR9KCk7cmV0dXJuIG50LnByb3RvdHlwZS50aGVuPWwsbnQuYWxsPU8sbnQucmFjZT1rLG50LnJlc29sdmU9aCxudC5yZWplY3Q9RixudC5fc2V0U2NoZWR1bGVyPW4sbnQuX3NldEFzYXA9cixudC5fYXNhcD16LG50LnBvbHlmaWxsPUQsbnQuUHJvbWlzZT1udCxudC5wb2x5ZmlsbCgpLG50fSk7\"")
 }
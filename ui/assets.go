@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+)
+
+// assetManifest lists the vendored assets within the static box
+const assetManifest = "vendor/assets.json"
+
+// vendorAsset is a third party file the ui bundles rather than loading it from a CDN, pinned to a version and to an
+// integrity hash in the format of subresource integrity, as in `sha384-<base64 digest>`
+type vendorAsset struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	URL       string `json:"url"`
+	Path      string `json:"path"`
+	Integrity string `json:"integrity"`
+}
+
+// checkAssets verifies the vendored assets found with find against the manifest, failing if any of them is missing
+// from the bundle, was altered or was added without being pinned, as GraphiQL would not load without them
+func checkAssets(find func(name string) ([]byte, error)) error {
+
+	assets, err := readAssetManifest(find)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		name := path.Join(path.Dir(assetManifest), asset.Path)
+		if asset.Integrity == "" {
+			return fmt.Errorf("ui asset `%s` is not pinned to an integrity hash", name)
+		}
+		content, err := find(name)
+		if err != nil || len(content) == 0 {
+			return fmt.Errorf("ui asset `%s` of %s@%s is not bundled, run `go generate ./ui` to bundle it", name, asset.Name, asset.Version)
+		}
+		if integrity(content) != asset.Integrity {
+			return fmt.Errorf("ui asset `%s` does not match its integrity hash", name)
+		}
+	}
+
+	return nil
+}
+
+func readAssetManifest(find func(name string) ([]byte, error)) ([]vendorAsset, error) {
+
+	bytes, err := find(assetManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the manifest of the ui assets: %s", err)
+	}
+
+	var assets []vendorAsset
+	if err := json.Unmarshal(bytes, &assets); err != nil {
+		return nil, fmt.Errorf("malformed manifest of the ui assets: %s", err)
+	}
+
+	return assets, nil
+}
+
+func integrity(content []byte) string {
+	digest := sha512.Sum384(content)
+	return "sha384-" + base64.StdEncoding.EncodeToString(digest[:])
+}
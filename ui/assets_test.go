@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gobuffalo/packr"
+)
+
+func TestAssets(t *testing.T) {
+
+	statics := packr.NewBox("./static")
+
+	if err := checkAssets(statics.Find); err != nil {
+		t.Fatalf("expected the bundled assets to match the manifest but got %s", err)
+	}
+
+	assets, err := readAssetManifest(statics.Find)
+	if err != nil {
+		t.Fatalf("error while reading the manifest: %s", err)
+	}
+	pinned := map[string]string{}
+	for _, asset := range assets {
+		pinned[path.Join(path.Dir(assetManifest), asset.Path)] = asset.Integrity
+	}
+
+	index, err := statics.FindString("index.html")
+	if err != nil {
+		t.Fatalf("error while reading index.html: %s", err)
+	}
+
+	// Every file GraphiQL loads is bundled, and vendored scripts carry their pinned hash
+	tags := regexp.MustCompile(`<(?:script|link)\b[^>]*>`).FindAllString(index, -1)
+	if len(tags) == 0 {
+		t.Fatalf("expected index.html to load its scripts and styles")
+	}
+	for _, tag := range tags {
+		ref := regexp.MustCompile(`(?:src|href)="([^"]*)"`).FindStringSubmatch(tag)
+		if ref == nil {
+			continue
+		}
+		if strings.Contains(ref[1], "//") {
+			t.Fatalf("expected the ui to load no asset from a CDN but found %s", tag)
+		}
+		if content, err := statics.Find(ref[1]); err != nil || len(content) == 0 {
+			t.Fatalf("expected `%s` loaded by index.html to be bundled", ref[1])
+		}
+		if hash, vendored := pinned[ref[1]]; vendored && !strings.Contains(tag, `integrity="`+hash+`"`) {
+			t.Fatalf("expected `%s` to be loaded with its pinned integrity hash", ref[1])
+		}
+	}
+
+	files := map[string]string{
+		assetManifest: `[
+			{"name": "a", "version": "1.0.0", "path": "a.js", "integrity": "` + integrity([]byte("a")) + `"},
+			{"name": "b", "version": "2.0.0", "path": "b.js", "integrity": "` + integrity([]byte("b")) + `"}
+		]`,
+		"vendor/a.js": "a",
+	}
+	find := func(name string) ([]byte, error) {
+		if content, ok := files[name]; ok {
+			return []byte(content), nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	if err := checkAssets(find); err == nil || !strings.Contains(err.Error(), "b@2.0.0") {
+		t.Fatalf("expected the missing b to be refused but got %v", err)
+	}
+
+	files["vendor/b.js"] = "altered"
+	if err := checkAssets(find); err == nil || !strings.Contains(err.Error(), "integrity") {
+		t.Fatalf("expected an altered asset to be refused but got %v", err)
+	}
+
+	files["vendor/b.js"] = "b"
+	if err := checkAssets(find); err != nil {
+		t.Fatalf("expected the assets to match but got %s", err)
+	}
+
+	files[assetManifest] = `[{"name": "c", "version": "3.0.0", "path": "c.js", "integrity": ""}]`
+	files["vendor/c.js"] = "c"
+	if err := checkAssets(find); err == nil || !strings.Contains(err.Error(), "not pinned") {
+		t.Fatalf("expected an unpinned asset to be refused but got %v", err)
+	}
+}
@@ -0,0 +1,392 @@
+package ui
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama/runtime"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/mastern2k3/poseidon/audit"
+	"github.com/mastern2k3/poseidon/graphql"
+)
+
+const (
+	// DefaultAdminCollection is the global storage collection BasicAuth reads admins from unless told otherwise
+	DefaultAdminCollection = "admin_users"
+
+	// DefaultSessionExpiry is how long session tokens are valid for unless told otherwise
+	DefaultSessionExpiry = time.Hour
+)
+
+var (
+	// ErrInvalidCredentials is returned for requests carrying credentials that are wrong or expired
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	unknownAdminOnce sync.Once
+	unknownAdmin     []byte
+)
+
+// Principal is an authenticated admin
+type Principal struct {
+	Name string
+	Role string
+}
+
+// Authenticator identifies the admin making a request, returning a nil principal if the request carries no
+// credentials it recognizes, so that the next authenticator is tried, and ErrInvalidCredentials if it carries wrong ones
+type Authenticator interface {
+	Authenticate(ctx context.Context, nk runtime.NakamaModule, r *http.Request) (*Principal, error)
+}
+
+// StaticTokens authenticates requests bearing one of its tokens, as in `Authorization: Bearer <token>`.
+// Other tokens are left to the next authenticator.
+type StaticTokens map[string]Principal
+
+func (tokens StaticTokens) Authenticate(ctx context.Context, nk runtime.NakamaModule, r *http.Request) (*Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, nil
+	}
+	// Every token is compared so that the time taken does not tell how close a guess was
+	var found *Principal
+	for candidate, principal := range tokens {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			p := principal
+			found = &p
+		}
+	}
+	return found, nil
+}
+
+// AdminUser is an admin allowed in with HTTP basic authentication, stored under its username
+type AdminUser struct {
+	PasswordHash string `json:"passwordHash"`
+	Role         string `json:"role"`
+}
+
+// BasicAuth authenticates requests with HTTP basic credentials, checked against the bcrypt hashed passwords of the
+// admins stored in a global storage collection.
+// Whoever can write global objects in the collection can make themselves an admin. Clients cannot write global objects,
+// the ui reserves the collection so that storage mutations refuse to change it, and admins are only trusted if they
+// are stored as SetPassword stores them, readable and writable by the server alone. Any other server code writing to
+// the collection is trusted as much as SetPassword.
+type BasicAuth struct {
+	// Collection holds the admins, DefaultAdminCollection when empty
+	Collection string
+}
+
+func (b *BasicAuth) Authenticate(ctx context.Context, nk runtime.NakamaModule, r *http.Request) (*Principal, error) {
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+
+	objs, err := nk.StorageRead(ctx, []*runtime.StorageRead{
+		&runtime.StorageRead{Collection: b.collection(), Key: username},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(objs) == 0 {
+		// Hashing anyway keeps unknown usernames from answering faster than wrong passwords
+		bcrypt.CompareHashAndPassword(unknownAdminHash(), []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+
+	if objs[0].GetPermissionRead() != 0 || objs[0].GetPermissionWrite() != 0 {
+		return nil, fmt.Errorf("admin `%s` is not stored as SetPassword stores admins, readable and writable by the server alone", username)
+	}
+
+	var admin AdminUser
+	if err := json.Unmarshal([]byte(objs[0].GetValue()), &admin); err != nil {
+		return nil, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{Name: username, Role: admin.Role}, nil
+}
+
+// SetPassword stores an admin with the bcrypt hash of password, replacing any admin with the same username
+func (b *BasicAuth) SetPassword(ctx context.Context, nk runtime.NakamaModule, username string, password string, role string) error {
+
+	if username == "" || password == "" {
+		return errors.New("an admin needs both a username and a password")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(&AdminUser{PasswordHash: string(hash), Role: role})
+	if err != nil {
+		return err
+	}
+
+	_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		&runtime.StorageWrite{
+			Collection:      b.collection(),
+			Key:             username,
+			Value:           string(bytes),
+			PermissionRead:  0,
+			PermissionWrite: 0,
+		},
+	})
+
+	return err
+}
+
+// unknownAdminHash is a hash no password matches, computed once as hashing is slow on purpose
+func unknownAdminHash() []byte {
+	unknownAdminOnce.Do(func() {
+		unknownAdmin, _ = bcrypt.GenerateFromPassword([]byte("unknown admin"), bcrypt.DefaultCost)
+	})
+	return unknownAdmin
+}
+
+func (b *BasicAuth) collection() string {
+	if b.Collection == "" {
+		return DefaultAdminCollection
+	}
+	return b.Collection
+}
+
+// SessionTokens authenticates requests bearing a session token, signed like Nakama's own sessions with a HS256 JWT.
+// Admins get a token from `/auth/login` by authenticating with any of the other authenticators.
+type SessionTokens struct {
+	// Key signs the tokens, tokens signed with another key are refused
+	Key []byte
+	// Expiry is how long tokens are valid for, DefaultSessionExpiry when zero
+	Expiry time.Duration
+}
+
+// sessionClaims are named as in Nakama's session tokens, with the admin's role in `rol`
+type sessionClaims struct {
+	Username string `json:"usn"`
+	Role     string `json:"rol"`
+	Expiry   int64  `json:"exp"`
+}
+
+var sessionHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+func (s *SessionTokens) Authenticate(ctx context.Context, nk runtime.NakamaModule, r *http.Request) (*Principal, error) {
+
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, nil
+	}
+
+	parts := strings.Split(token, ".")
+	// Tokens which are not JWTs may be meant for another authenticator
+	if len(parts) != 3 {
+		return nil, nil
+	}
+
+	if parts[0] != sessionHeader || !hmac.Equal([]byte(parts[2]), []byte(s.sign(parts[0]+"."+parts[1]))) {
+		return nil, ErrInvalidCredentials
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	var claims sessionClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Username == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	if time.Now().Unix() >= claims.Expiry {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &Principal{Name: claims.Username, Role: claims.Role}, nil
+}
+
+// Issue creates a session token for principal, returning it along with its expiry
+func (s *SessionTokens) Issue(principal *Principal) (string, time.Time, error) {
+
+	expiry := s.Expiry
+	if expiry == 0 {
+		expiry = DefaultSessionExpiry
+	}
+	exp := time.Now().Add(expiry)
+
+	payload, err := json.Marshal(&sessionClaims{Username: principal.Name, Role: principal.Role, Expiry: exp.Unix()})
+	if err != nil {
+		return "", exp, err
+	}
+
+	unsigned := sessionHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return unsigned + "." + s.sign(unsigned), exp, nil
+}
+
+func (s *SessionTokens) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, s.Key)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// authorizer identifies the admins making requests and what they may do
+type authorizer struct {
+	nk             runtime.NakamaModule
+	authenticators []Authenticator
+	roles          map[string][]string
+	allowedOrigins []string
+}
+
+// required tells whether requests must be authenticated, which they need not be when no authenticator is configured
+func (a *authorizer) required() bool {
+	return len(a.authenticators) > 0
+}
+
+// ambient tells whether browsers send the credentials of requests on their own, as they do for basic credentials,
+// which lets other sites make requests on behalf of an admin
+func (a *authorizer) ambient() bool {
+	for _, authenticator := range a.authenticators {
+		if _, ok := authenticator.(*BasicAuth); ok {
+			return true
+		}
+	}
+	return !a.required()
+}
+
+// allowsOrigin tells whether r was sent from the ui itself, from one of the allowed origins or by a client other than
+// a browser, which sends no `Origin`
+func (a *authorizer) allowsOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && u.Host == r.Host {
+		return true
+	}
+	return a.allowedOrigin(origin)
+}
+
+// allowedOrigin tells whether origin is one of the allowed origins
+func (a *authorizer) allowedOrigin(origin string) bool {
+	for _, allowed := range a.allowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate returns the admin making r, nil if r carries no credentials
+func (a *authorizer) authenticate(ctx context.Context, r *http.Request) (*Principal, error) {
+	return a.authenticateWith(ctx, r, a.authenticators)
+}
+
+func (a *authorizer) authenticateWith(ctx context.Context, r *http.Request, authenticators []Authenticator) (*Principal, error) {
+	for _, authenticator := range authenticators {
+		principal, err := authenticator.Authenticate(ctx, a.nk, r)
+		if err != nil || principal != nil {
+			return principal, err
+		}
+	}
+	return nil, nil
+}
+
+// authenticateHeader authenticates the value of an `Authorization` header sent outside of the HTTP headers,
+// such as in the payload starting a websocket session
+func (a *authorizer) authenticateHeader(ctx context.Context, authorization string) (*Principal, error) {
+	r := &http.Request{Header: http.Header{}}
+	r.Header.Set("Authorization", authorization)
+	return a.authenticate(ctx, r)
+}
+
+// context attributes the changes made with ctx to principal, and restricts the mutations it executes to its role's
+func (a *authorizer) context(ctx context.Context, principal *Principal) context.Context {
+	if principal == nil {
+		return ctx
+	}
+	ctx = audit.WithActor(ctx, principal.Name)
+	if a.roles == nil {
+		return ctx
+	}
+	return graphql.WithAllowedMutations(ctx, a.roles[principal.Role])
+}
+
+// challenge refuses an unauthenticated request, prompting browsers for basic credentials if they are accepted
+func (a *authorizer) challenge(w http.ResponseWriter, err error) {
+	for _, authenticator := range a.authenticators {
+		if _, ok := authenticator.(*BasicAuth); ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="poseidon", charset="UTF-8"`)
+		}
+	}
+	if err == nil {
+		err = errors.New("authentication required")
+	}
+	writeError(w, http.StatusUnauthorized, err)
+}
+
+// loginHandler exchanges the credentials of any authenticator other than sessions for a session token
+func (a *authorizer) loginHandler(logger runtime.Logger, sessions *SessionTokens) http.HandlerFunc {
+
+	others := []Authenticator{}
+	for _, authenticator := range a.authenticators {
+		if authenticator != sessions {
+			others = append(others, authenticator)
+		}
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method `%s` is not allowed", r.Method))
+			return
+		}
+
+		if !a.allowsOrigin(r) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("origin `%s` is not allowed", r.Header.Get("Origin")))
+			return
+		}
+
+		principal, err := a.authenticateWith(r.Context(), r, others)
+		if err != nil && err != ErrInvalidCredentials {
+			logger.Error("failed to authenticate admin login: %s", err)
+		}
+		if err != nil || principal == nil {
+			a.challenge(w, err)
+			return
+		}
+
+		token, exp, err := sessions.Issue(principal)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeJSON(w, logger, map[string]interface{}{"token": token, "expiry": exp.Unix()})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "bearer "
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(authorization[len(prefix):]), true
+}
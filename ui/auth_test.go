@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/heroiclabs/nakama/runtime"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/mastern2k3/poseidon/audit"
+	"github.com/mastern2k3/poseidon/graphql"
+	"github.com/mastern2k3/poseidon/tests/fake"
+)
+
+func postGraphQL(t *testing.T, url string, query string, authorize func(r *http.Request)) (int, *graphql.GraphQLResponse) {
+	body, _ := json.Marshal(&graphql.GraphQLRequest{Query: query})
+	r, _ := http.NewRequest(http.MethodPost, url+"/graphql", strings.NewReader(string(body)))
+	r.Header.Set("Content-Type", "application/json")
+	authorize(r)
+	resp, err := http.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatalf("error while posting: %s", err)
+	}
+	var gqlResp graphql.GraphQLResponse
+	decodeBody(t, resp, &gqlResp)
+	return resp.StatusCode, &gqlResp
+}
+
+func bearer(token string) func(r *http.Request) {
+	return func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) }
+}
+
+func basic(username, password string) func(r *http.Request) {
+	return func(r *http.Request) { r.SetBasicAuth(username, password) }
+}
+
+func TestAuthentication(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+
+	basicAuth := &BasicAuth{}
+	if err := basicAuth.SetPassword(context.Background(), nk, "root", "secret", "admin"); err != nil {
+		t.Fatalf("error while setting a password: %s", err)
+	}
+
+	sessions := &SessionTokens{Key: []byte(strings.Repeat("k", minSessionKeySize))}
+
+	srv := newTestServer(t, nk, Options{
+		Authenticators: []Authenticator{
+			StaticTokens{"support-token": {Name: "support", Role: "support"}},
+			basicAuth,
+			sessions,
+		},
+		Roles: map[string][]string{
+			"admin":   {graphql.AllMutations},
+			"support": {"sendToStream"},
+		},
+	})
+	defer srv.Close()
+
+	query := `{ matches { id } }`
+	mutation := `mutation { writeStorage(collection: "c", key: "k", value: "{}") { version } }`
+
+	if status, _ := postGraphQL(t, srv.URL, query, func(r *http.Request) {}); status != http.StatusUnauthorized {
+		t.Fatalf("expected unauthenticated requests to be refused but got status %d", status)
+	}
+	if status, _ := postGraphQL(t, srv.URL, query, basic("root", "wrong")); status != http.StatusUnauthorized {
+		t.Fatalf("expected a wrong password to be refused but got status %d", status)
+	}
+	if status, _ := postGraphQL(t, srv.URL, query, bearer("unknown")); status != http.StatusUnauthorized {
+		t.Fatalf("expected an unknown token to be refused but got status %d", status)
+	}
+
+	if _, resp := postGraphQL(t, srv.URL, query, bearer("support-token")); len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	if _, resp := postGraphQL(t, srv.URL, mutation, bearer("support-token")); len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "not permitted") {
+		t.Fatalf("expected the support role to be refused the mutation but got %+v", resp)
+	}
+
+	login, _ := http.NewRequest(http.MethodPost, srv.URL+"/auth/login", nil)
+	login.SetBasicAuth("root", "secret")
+	resp, err := http.DefaultClient.Do(login)
+	if err != nil {
+		t.Fatalf("error while logging in: %s", err)
+	}
+	var session struct {
+		Token string `json:"token"`
+	}
+	decodeBody(t, resp, &session)
+	if session.Token == "" {
+		t.Fatalf("expected a session token")
+	}
+
+	if _, resp := postGraphQL(t, srv.URL, mutation, bearer(session.Token)); len(resp.Errors) > 0 {
+		t.Fatalf("expected the admin to be allowed the mutation but got %+v", resp.Errors)
+	}
+
+	entries, _, err := nk.StorageList(context.Background(), "", audit.CollectionID, 10, "")
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected a single audit entry but got %+v, %v", entries, err)
+	}
+	var entry audit.Entry
+	if err := json.Unmarshal([]byte(entries[0].GetValue()), &entry); err != nil || entry.Actor != "root" || entry.RequestID == "" {
+		t.Fatalf("expected the change to be attributed to the admin within a request but got %+v, %v", entry, err)
+	}
+
+	tampered := session.Token[:len(session.Token)-2] + "xx"
+	if status, _ := postGraphQL(t, srv.URL, query, bearer(tampered)); status != http.StatusUnauthorized {
+		t.Fatalf("expected a tampered token to be refused but got status %d", status)
+	}
+
+	if _, resp := postGraphQL(t, srv.URL, `mutation { writeStorage(collection: "admin_users", key: "intruder", value: "{}") { version } }`, bearer(session.Token)); len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "reserved") {
+		t.Fatalf("expected the admin collection to be reserved but got %+v", resp)
+	}
+
+	// Admins written with permissions other than those SetPassword writes are not trusted
+	hash, _ := bcrypt.GenerateFromPassword([]byte("open"), bcrypt.MinCost)
+	value, _ := json.Marshal(&AdminUser{PasswordHash: string(hash), Role: "admin"})
+	if _, err := nk.StorageWrite(context.Background(), []*runtime.StorageWrite{
+		&runtime.StorageWrite{Collection: DefaultAdminCollection, Key: "public", Value: string(value), PermissionRead: 2, PermissionWrite: 1},
+	}); err != nil {
+		t.Fatalf("error while writing an admin: %s", err)
+	}
+	if status, _ := postGraphQL(t, srv.URL, query, basic("public", "open")); status == http.StatusOK {
+		t.Fatalf("expected an admin writable by others to be refused")
+	}
+}
+
+func TestForgedRequests(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+
+	basicAuth := &BasicAuth{}
+	if err := basicAuth.SetPassword(context.Background(), nk, "root", "secret", "admin"); err != nil {
+		t.Fatalf("error while setting a password: %s", err)
+	}
+
+	srv := newTestServer(t, nk, Options{
+		Authenticators: []Authenticator{basicAuth},
+		AllowedOrigins: []string{"https://altair.example.com"},
+	})
+	defer srv.Close()
+
+	post := func(contentType string, origin string) *http.Response {
+		body := `{"query": "mutation { writeStorage(collection: \"c\", key: \"k\", value: \"{}\") { version } }"}`
+		r, _ := http.NewRequest(http.MethodPost, srv.URL+"/graphql", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		r.SetBasicAuth("root", "secret")
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			t.Fatalf("error while posting: %s", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	if resp := post("text/plain", ""); resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected a text/plain body to be refused but got status %d", resp.StatusCode)
+	}
+	if resp := post("application/json", "https://evil.example.com"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a request from another site to be refused but got status %d", resp.StatusCode)
+	}
+	if resp := post("application/json", "https://evil.example.com"); resp.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Fatalf("expected no origin to be allowed with basic credentials but got `%s`", resp.Header.Get("Access-Control-Allow-Origin"))
+	}
+	if entries, _, err := nk.StorageList(context.Background(), "", "c", 10, ""); err != nil || len(entries) != 0 {
+		t.Fatalf("expected the refused requests not to write but got %+v, %v", entries, err)
+	}
+
+	if resp := post("application/json", "https://altair.example.com"); resp.StatusCode != http.StatusOK || resp.Header.Get("Access-Control-Allow-Origin") != "https://altair.example.com" {
+		t.Fatalf("expected an allowed origin to be served but got status %d and origin `%s`", resp.StatusCode, resp.Header.Get("Access-Control-Allow-Origin"))
+	}
+	if resp := post("application/json", srv.URL); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected a request from the ui itself to be served but got status %d", resp.StatusCode)
+	}
+}
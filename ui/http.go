@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/audit"
+	"github.com/mastern2k3/poseidon/graphql"
+)
+
+const (
+	// maxBodySize bounds the size of the body of GraphQL requests
+	maxBodySize = 1 << 20
+	// maxBatchSize bounds the number of operations executed for a batched request
+	maxBatchSize = 32
+	// maxRequestIDSize bounds the size of request ids taken from the `X-Request-Id` header
+	maxRequestIDSize = 128
+)
+
+// graphqlHandler executes GraphQL requests sent with GET, POST or over a websocket, with the NakamaModule given at init.
+// POST requests may batch operations as a json array, and are answered with an array of responses in the same order.
+func graphqlHandler(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, auth *authorizer) http.HandlerFunc {
+
+	subscriptions := subscriptionsHandler(ctx, logger, db, nk, auth)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Lets browser based clients such as Altair call the endpoint from their own origin. Every site may read
+		// responses only when browsers cannot send credentials on their own, as they do basic credentials.
+		if origin := r.Header.Get("Origin"); auth.allowedOrigin(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+		} else if !auth.ambient() {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Request-Id")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-Id")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+
+		switch {
+		case isWebsocketUpgrade(r):
+			// Browsers cannot set headers on websockets, so sessions may also authenticate once started
+			subscriptions(w, r)
+			return
+		case r.Method == http.MethodOptions:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case !auth.allowsOrigin(r):
+			writeError(w, http.StatusForbidden, fmt.Errorf("origin `%s` is not allowed", r.Header.Get("Origin")))
+			return
+		}
+
+		principal, err := auth.authenticate(r.Context(), r)
+		if err != nil && err != ErrInvalidCredentials {
+			logger.Error("failed to authenticate graphql request: %s", err)
+		}
+		if err != nil || (principal == nil && auth.required()) {
+			auth.challenge(w, err)
+			return
+		}
+
+		ctx := auth.context(context.WithValue(context.WithValue(r.Context(), graphql.GRAPHQL_CTX_DB, db), graphql.GRAPHQL_CTX_LOGGER, logger), principal)
+		ctx = audit.WithRequestID(ctx, requestID(w, r))
+
+		switch r.Method {
+		case http.MethodGet:
+			request, err := getRequest(r)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			writeJSON(w, logger, graphql.ExecuteQuery(ctx, nk, request))
+		case http.MethodPost:
+			// Only bodies browsers cannot send across sites without asking first are accepted, which keeps other
+			// sites from forging mutations with the credentials browsers send on their own
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if mediaType != "application/json" && mediaType != "application/graphql" {
+				writeError(w, http.StatusUnsupportedMediaType, fmt.Errorf("content type `%s` is not supported, expected `application/json` or `application/graphql`", r.Header.Get("Content-Type")))
+				return
+			}
+			requests, batched, err := postRequests(r, mediaType)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			responses := make([]*graphql.GraphQLResponse, len(requests))
+			for i, request := range requests {
+				logger.Debug("graphql operation `%s`: %s", request.OperationName, request.Query)
+				responses[i] = graphql.Execute(ctx, nk, request)
+			}
+			if batched {
+				writeJSON(w, logger, responses)
+			} else {
+				writeJSON(w, logger, responses[0])
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST, OPTIONS")
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method `%s` is not allowed", r.Method))
+		}
+	}
+}
+
+// getRequest reads a request from the query string, where variables and extensions are json encoded
+func getRequest(r *http.Request) (*graphql.GraphQLRequest, error) {
+
+	params := r.URL.Query()
+
+	request := &graphql.GraphQLRequest{
+		Query:         params.Get("query"),
+		OperationName: params.Get("operationName"),
+	}
+
+	if variables := params.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+			return nil, fmt.Errorf("malformed `variables`: %s", err)
+		}
+	}
+
+	if extensions := params.Get("extensions"); extensions != "" {
+		if err := json.Unmarshal([]byte(extensions), &request.Extensions); err != nil {
+			return nil, fmt.Errorf("malformed `extensions`: %s", err)
+		}
+	}
+
+	return request, nil
+}
+
+// postRequests reads the requests of a body of mediaType holding a query, a request or an array of requests
+func postRequests(r *http.Request, mediaType string) (requests []*graphql.GraphQLRequest, batched bool, err error) {
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read the body: %s", err)
+	}
+
+	if mediaType == "application/graphql" {
+		return []*graphql.GraphQLRequest{{Query: string(body)}}, false, nil
+	}
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &requests); err != nil {
+			return nil, false, fmt.Errorf("malformed batch: %s", err)
+		}
+		if len(requests) == 0 || len(requests) > maxBatchSize {
+			return nil, false, fmt.Errorf("a batch must hold between 1 and %d operations", maxBatchSize)
+		}
+		for _, request := range requests {
+			if request == nil {
+				return nil, false, fmt.Errorf("a batch must only hold operations")
+			}
+		}
+		return requests, true, nil
+	}
+
+	request := &graphql.GraphQLRequest{}
+	if err := json.Unmarshal(body, request); err != nil {
+		return nil, false, fmt.Errorf("malformed request: %s", err)
+	}
+	return []*graphql.GraphQLRequest{request}, false, nil
+}
+
+// requestID returns the id the changes made by r are audited under, which is taken from the `X-Request-Id` header
+// set by proxies or clients when present, and echoed back in the response
+func requestID(w http.ResponseWriter, r *http.Request) string {
+	id := r.Header.Get("X-Request-Id")
+	if id == "" || len(id) > maxRequestIDSize {
+		id = audit.NewRequestID()
+	}
+	w.Header().Set("X-Request-Id", id)
+	return id
+}
+
+func writeJSON(w http.ResponseWriter, logger runtime.Logger, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		logger.Error("failed to write graphql response: %s", err)
+	}
+}
+
+// writeError responds with a GraphQL response holding err, as clients expect even for requests that were not executed
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&graphql.GraphQLResponse{Errors: []graphql.GraphQLError{{Message: err.Error()}}})
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mastern2k3/poseidon/graphql"
+	"github.com/mastern2k3/poseidon/rpc/rpctest"
+	"github.com/mastern2k3/poseidon/tests/fake"
+)
+
+func newTestServer(t *testing.T, nk *fake.NakamaModule, opts Options) *httptest.Server {
+	if err := graphql.RegisterGraphQL(rpctest.New(t, nk)); err != nil {
+		t.Fatalf("error while registering graphql: %s", err)
+	}
+	auth := &authorizer{nk: nk, authenticators: opts.Authenticators, roles: opts.Roles, allowedOrigins: opts.AllowedOrigins}
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", graphqlHandler(context.Background(), &rpctest.Logger{T: t}, nil, nk, auth))
+	for _, authenticator := range opts.Authenticators {
+		if sessions, ok := authenticator.(*SessionTokens); ok {
+			mux.Handle("/auth/login", auth.loginHandler(&rpctest.Logger{T: t}, sessions))
+		}
+	}
+	return httptest.NewServer(mux)
+}
+
+func decodeBody(t *testing.T, resp *http.Response, value interface{}) {
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+		t.Fatalf("error while decoding the response: %s", err)
+	}
+}
+
+func TestGraphQLOverHTTP(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+	userID := nk.AddUser("someone")
+
+	srv := newTestServer(t, nk, Options{})
+	defer srv.Close()
+
+	query := `{ userByUsername(username: "someone") { id } }`
+
+	resp, err := http.Get(srv.URL + "/graphql?query=" + url.QueryEscape(query))
+	if err != nil {
+		t.Fatalf("error while getting: %s", err)
+	}
+	var single graphql.GraphQLResponse
+	decodeBody(t, resp, &single)
+	if len(single.Errors) > 0 || single.Data.(map[string]interface{})["userByUsername"].(map[string]interface{})["id"] != userID {
+		t.Fatalf("unexpected response %+v", single)
+	}
+
+	resp, err = http.Post(srv.URL+"/graphql", "application/json", strings.NewReader(`[
+		{"query": `+jsonString(query)+`},
+		{"query": "{ nothing }"}
+	]`))
+	if err != nil {
+		t.Fatalf("error while posting: %s", err)
+	}
+	var batch []graphql.GraphQLResponse
+	decodeBody(t, resp, &batch)
+	if len(batch) != 2 || len(batch[0].Errors) > 0 || len(batch[1].Errors) == 0 {
+		t.Fatalf("expected a response per operation in order but got %+v", batch)
+	}
+
+	resp, err = http.Get(srv.URL + "/graphql?query=" + url.QueryEscape(`mutation { deleteStorage(collection: "c", key: "k") }`))
+	if err != nil {
+		t.Fatalf("error while getting: %s", err)
+	}
+	single = graphql.GraphQLResponse{}
+	decodeBody(t, resp, &single)
+	if len(single.Errors) == 0 || !strings.Contains(single.Errors[0].Message, "mutation") {
+		t.Fatalf("expected mutations to be refused over GET but got %+v", single)
+	}
+
+	resp, err = http.Post(srv.URL+"/graphql", "application/json", strings.NewReader(`{"query":`))
+	if err != nil {
+		t.Fatalf("error while posting: %s", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a malformed request to be refused but got status %d", resp.StatusCode)
+	}
+	resp.Body.Close()
+}
+
+func jsonString(s string) string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
+
+func TestWebsocketOrigin(t *testing.T) {
+
+	nk := fake.NewNakamaModule()
+
+	srv := newTestServer(t, nk, Options{AllowedOrigins: []string{"https://altair.example.com"}})
+	defer srv.Close()
+
+	upgrade := func(origin string) int {
+		r, _ := http.NewRequest(http.MethodGet, srv.URL+"/graphql", nil)
+		r.Header.Set("Connection", "Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		r.Header.Set("Sec-Websocket-Version", "13")
+		r.Header.Set("Sec-Websocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		r.Header.Set("Sec-Websocket-Protocol", graphqlWSProtocol)
+		r.Header.Set("Origin", origin)
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			t.Fatalf("error while upgrading: %s", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := upgrade("https://evil.example.com"); status != http.StatusForbidden {
+		t.Fatalf("expected a websocket from another site to be refused but got status %d", status)
+	}
+	if status := upgrade("https://altair.example.com"); status != http.StatusSwitchingProtocols {
+		t.Fatalf("expected a websocket from an allowed origin to be upgraded but got status %d", status)
+	}
+	if status := upgrade(srv.URL); status != http.StatusSwitchingProtocols {
+		t.Fatalf("expected a websocket from the ui itself to be upgraded but got status %d", status)
+	}
+}
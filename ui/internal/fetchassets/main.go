@@ -0,0 +1,170 @@
+// Command fetchassets downloads the assets listed in the manifest of the ui's vendored assets next to it, checking
+// each one against its integrity hash. Assets without one are pinned to the hash of what was downloaded, which is
+// then written back to the manifest to be reviewed and committed, and the page loading the assets is given the hashes
+// as integrity attributes. With -verify, the assets already downloaded and the page are checked without changing
+// anything.
+package main
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+type asset struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	URL       string `json:"url"`
+	Path      string `json:"path"`
+	Integrity string `json:"integrity"`
+}
+
+func main() {
+
+	manifest := flag.String("manifest", "static/vendor/assets.json", "the manifest listing the assets to download")
+	index := flag.String("index", "static/index.html", "the page loading the assets, given their integrity hashes")
+	verify := flag.Bool("verify", false, "only check the assets already downloaded")
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(*manifest)
+	if err != nil {
+		log.Fatalf("failed to read the manifest: %s", err)
+	}
+
+	var assets []*asset
+	if err := json.Unmarshal(data, &assets); err != nil {
+		log.Fatalf("malformed manifest: %s", err)
+	}
+
+	dir := filepath.Dir(*manifest)
+	client := &http.Client{Timeout: time.Minute}
+	pinned := false
+
+	for _, a := range assets {
+		file := filepath.Join(dir, filepath.FromSlash(a.Path))
+
+		var content []byte
+		if *verify {
+			content, err = ioutil.ReadFile(file)
+		} else {
+			content, err = download(client, a.URL)
+		}
+		if err != nil {
+			log.Fatalf("failed to get %s@%s: %s", a.Name, a.Version, err)
+		}
+
+		hash := integrity(content)
+		switch {
+		case a.Integrity == "" && *verify:
+			log.Fatalf("%s@%s is not pinned to an integrity hash", a.Name, a.Version)
+		case a.Integrity == "":
+			log.Printf("pinning %s@%s to %s", a.Name, a.Version, hash)
+			a.Integrity = hash
+			pinned = true
+		case a.Integrity != hash:
+			log.Fatalf("%s@%s does not match its integrity hash, expected %s but got %s", a.Name, a.Version, a.Integrity, hash)
+		}
+
+		if !*verify {
+			if err := ioutil.WriteFile(file, content, 0644); err != nil {
+				log.Fatalf("failed to write %s: %s", file, err)
+			}
+		}
+	}
+
+	if pinned {
+		if err := writeManifest(*manifest, assets); err != nil {
+			log.Fatalf("failed to write the manifest: %s", err)
+		}
+	}
+
+	page, err := ioutil.ReadFile(*index)
+	if err != nil {
+		log.Fatalf("failed to read the page loading the assets: %s", err)
+	}
+	hashed := hashPage(page, path.Base(filepath.ToSlash(dir)), assets)
+	switch {
+	case bytes.Equal(hashed, page):
+	case *verify:
+		log.Fatalf("%s does not load the assets with their integrity hashes", *index)
+	default:
+		if err := ioutil.WriteFile(*index, hashed, 0644); err != nil {
+			log.Fatalf("failed to write %s: %s", *index, err)
+		}
+	}
+}
+
+var (
+	tagPattern       = regexp.MustCompile(`<(?:script|link)\b[^>]*>`)
+	refPattern       = regexp.MustCompile(`\b(?:src|href)="([^"]*)"`)
+	integrityPattern = regexp.MustCompile(`\bintegrity="[^"]*"`)
+)
+
+// hashPage sets the integrity attribute of the script and link tags of page which load assets from the directory
+// dir to their integrity hash
+func hashPage(page []byte, dir string, assets []*asset) []byte {
+
+	hashes := map[string]string{}
+	for _, a := range assets {
+		hashes[path.Join(dir, a.Path)] = a.Integrity
+	}
+
+	return tagPattern.ReplaceAllFunc(page, func(tag []byte) []byte {
+		ref := refPattern.FindSubmatchIndex(tag)
+		if ref == nil {
+			return tag
+		}
+		hash, ok := hashes[string(tag[ref[2]:ref[3]])]
+		if !ok {
+			return tag
+		}
+		attribute := []byte(`integrity="` + hash + `"`)
+		if integrityPattern.Match(tag) {
+			return integrityPattern.ReplaceAllLiteral(tag, attribute)
+		}
+		hashed := append([]byte{}, tag[:ref[1]]...)
+		hashed = append(hashed, ' ')
+		hashed = append(hashed, attribute...)
+		return append(hashed, tag[ref[1]:]...)
+	})
+}
+
+func download(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("`%s` answered with status %d", url, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func writeManifest(name string, assets []*asset) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(assets); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, buf.Bytes(), os.FileMode(0644))
+}
+
+// integrity hashes content in the format of subresource integrity, as the ui checks it
+func integrity(content []byte) string {
+	digest := sha512.Sum384(content)
+	return "sha384-" + base64.StdEncoding.EncodeToString(digest[:])
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/heroiclabs/nakama/runtime"
+)
+
+const (
+	// DefaultAddress is where the ui server listens unless told otherwise
+	DefaultAddress = ":8090"
+
+	// DefaultShutdownTimeout is how long requests in flight are waited for on shutdown unless told otherwise
+	DefaultShutdownTimeout = 10 * time.Second
+
+	// readyTimeout bounds how long readiness probes wait for the database
+	readyTimeout = 2 * time.Second
+)
+
+// listen binds the address of opts, listening with TLS if it is given a certificate
+func listen(ctx context.Context, opts Options) (net.Listener, error) {
+
+	address := setting(ctx, opts.Address, "POSEIDON_UI_ADDRESS")
+	if address == "" {
+		address = DefaultAddress
+	}
+	// A lone port listens on every interface
+	if _, err := strconv.Atoi(address); err == nil {
+		address = ":" + address
+	}
+
+	certFile := setting(ctx, opts.CertFile, "POSEIDON_UI_CERT_FILE")
+	keyFile := setting(ctx, opts.KeyFile, "POSEIDON_UI_KEY_FILE")
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("the ui server needs both a certificate and a key file to serve TLS")
+	}
+
+	var config *tls.Config
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the certificate of the ui server: %s", err)
+		}
+		config = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on `%s` for the ui server: %s", address, err)
+	}
+
+	if config != nil {
+		return tls.NewListener(listener, config), nil
+	}
+	return listener, nil
+}
+
+// setting returns value if it is set, or else the environment variable named, or else the key of Nakama's runtime
+// env named alike in lower case, as in `poseidon_ui_address`
+func setting(ctx context.Context, value string, name string) string {
+	if value != "" {
+		return value
+	}
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	env, _ := ctx.Value(runtime.RUNTIME_CTX_ENV).(map[string]string)
+	return env[strings.ToLower(name)]
+}
+
+// serve serves srv on listener until ctx is done, then waits up to timeout for the requests in flight to finish
+// before closing stopped. Websockets are hijacked connections the server does not track, they are closed on their
+// own as ctx is done.
+func serve(ctx context.Context, logger runtime.Logger, srv *http.Server, listener net.Listener, ready *int32, timeout time.Duration, stopped chan<- struct{}) {
+
+	if timeout == 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	go func() {
+		defer close(stopped)
+
+		<-ctx.Done()
+		atomic.StoreInt32(ready, 0)
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("the ui server did not shut down cleanly: %s", err)
+			return
+		}
+		logger.Info("the ui server shut down")
+	}()
+
+	atomic.StoreInt32(ready, 1)
+
+	if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+		atomic.StoreInt32(ready, 0)
+		logger.Error("the ui server stopped: %s", err)
+	}
+}
+
+// healthHandler answers liveness probes, the server being alive as long as it answers
+func healthHandler(logger runtime.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, logger, map[string]string{"status": "ok"})
+	}
+}
+
+// readyHandler answers readiness probes, failing while the server is shutting down or the database is unreachable
+func readyHandler(logger runtime.Logger, db *sql.DB, ready *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if atomic.LoadInt32(ready) == 0 {
+			writeStatus(w, logger, http.StatusServiceUnavailable, "shutting down")
+			return
+		}
+
+		if db != nil {
+			ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+			defer cancel()
+			if err := db.PingContext(ctx); err != nil {
+				logger.Warn("the ui server is not ready, the database is unreachable: %s", err)
+				writeStatus(w, logger, http.StatusServiceUnavailable, "database unreachable")
+				return
+			}
+		}
+
+		writeJSON(w, logger, map[string]string{"status": "ready"})
+	}
+}
+
+func writeStatus(w http.ResponseWriter, logger runtime.Logger, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]string{"status": message}); err != nil {
+		logger.Error("failed to write status: %s", err)
+	}
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/rpc/rpctest"
+	"github.com/mastern2k3/poseidon/tests/fake"
+)
+
+func TestServerLifecycle(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), runtime.RUNTIME_CTX_ENV, map[string]string{
+		"poseidon_ui_address": "127.0.0.1:0",
+	}))
+	defer cancel()
+
+	addr, stopped, err := start(ctx, &rpctest.Logger{T: t}, nil, fake.NewNakamaModule(), Options{})
+	if err != nil {
+		t.Fatalf("error while starting the server: %s", err)
+	}
+	url := "http://" + addr.String()
+
+	// Connections the client dials ahead of time without sending requests on them would hold the shutdown up
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		resp, err := client.Get(url + path)
+		if err != nil {
+			t.Fatalf("error while probing %s: %s", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected %s to succeed but got status %d", path, resp.StatusCode)
+		}
+	}
+
+	if _, _, err := start(ctx, &rpctest.Logger{T: t}, nil, fake.NewNakamaModule(), Options{Address: addr.String()}); err == nil {
+		t.Fatalf("expected an address in use to be refused")
+	}
+
+	cancel()
+	awaitShutdown(t, stopped)
+
+	if _, err := client.Get(url + "/healthz"); err == nil {
+		t.Fatalf("expected the server to stop listening once shut down")
+	}
+}
+
+func TestServerTLS(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "poseidon-ui")
+	if err != nil {
+		t.Fatalf("error while creating a directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, _, err := start(ctx, &rpctest.Logger{T: t}, nil, fake.NewNakamaModule(), Options{Address: "127.0.0.1:0", CertFile: certFile}); err == nil {
+		t.Fatalf("expected a certificate without a key to be refused")
+	}
+
+	addr, stopped, err := start(ctx, &rpctest.Logger{T: t}, nil, fake.NewNakamaModule(), Options{Address: "127.0.0.1:0", CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("error while starting the server: %s", err)
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Get("https://" + addr.String() + "/healthz")
+	if err != nil {
+		t.Fatalf("error while probing over TLS: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.TLS == nil {
+		t.Fatalf("expected the probe to succeed over TLS but got status %d", resp.StatusCode)
+	}
+
+	cancel()
+	awaitShutdown(t, stopped)
+}
+
+// awaitShutdown waits for a server to shut down, so that it does not log once the test is over
+func awaitShutdown(t *testing.T, stopped <-chan struct{}) {
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the server to shut down once the context is done")
+	}
+}
+
+// writeTestCertificate writes a self signed certificate for localhost and its key to dir
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error while generating a key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error while creating a certificate: %s", err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("error while encoding the key: %s", err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0600); err != nil {
+		t.Fatalf("error while writing the certificate: %s", err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600); err != nil {
+		t.Fatalf("error while writing the key: %s", err)
+	}
+
+	return certFile, keyFile
+}
@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/audit"
+	"github.com/mastern2k3/poseidon/graphql"
+)
+
+// The messages of the graphql-ws protocol, as spoken by subscriptions-transport-ws clients
+const (
+	graphqlWSProtocol = "graphql-ws"
+
+	gqlConnectionInit      = "connection_init"
+	gqlConnectionAck       = "connection_ack"
+	gqlConnectionError     = "connection_error"
+	gqlConnectionKeepAlive = "ka"
+	gqlConnectionTerminate = "connection_terminate"
+	gqlStart               = "start"
+	gqlData                = "data"
+	gqlError               = "error"
+	gqlComplete            = "complete"
+	gqlStop                = "stop"
+
+	keepAliveInterval = 20 * time.Second
+)
+
+// gqlConnectionParams are the parameters a client sends when starting a session
+type gqlConnectionParams struct {
+	Authorization string `json:"authorization"`
+}
+
+type gqlMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// subscriptionsHandler serves GraphQL operations over websockets, subscriptions included
+func subscriptionsHandler(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, auth *authorizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Browsers let every site open websockets, sending basic credentials along, so only the ui itself and the
+		// allowed origins may start sessions from a browser
+		if !auth.allowsOrigin(r) {
+			logger.Warn("refused a graphql websocket from origin `%s`", r.Header.Get("Origin"))
+			writeError(w, http.StatusForbidden, fmt.Errorf("origin `%s` is not allowed", r.Header.Get("Origin")))
+			return
+		}
+
+		// Requests with wrong credentials are refused right away, those without any may still authenticate
+		// with the `authorization` parameter of `connection_init`
+		principal, err := auth.authenticate(r.Context(), r)
+		if err != nil {
+			if err != ErrInvalidCredentials {
+				logger.Error("failed to authenticate graphql websocket: %s", err)
+			}
+			auth.challenge(w, err)
+			return
+		}
+
+		conn, err := upgradeWebsocket(w, r, graphqlWSProtocol)
+		if err != nil {
+			logger.Warn("failed to upgrade graphql websocket: %s", err)
+			return
+		}
+
+		s := &wsSession{
+			conn:       conn,
+			logger:     logger,
+			nk:         nk,
+			auth:       auth,
+			principal:  principal,
+			operations: map[string]*wsOperation{},
+		}
+		s.serve(context.WithValue(context.WithValue(ctx, graphql.GRAPHQL_CTX_DB, db), graphql.GRAPHQL_CTX_LOGGER, logger))
+	}
+}
+
+// wsSession runs the operations started on one websocket, each until it completes or is stopped
+type wsSession struct {
+	conn          *wsConn
+	logger        runtime.Logger
+	nk            runtime.NakamaModule
+	auth          *authorizer
+	principal     *Principal
+	keepAliveOnce sync.Once
+	mutex         sync.Mutex
+	operations    map[string]*wsOperation
+}
+
+type wsOperation struct {
+	cancel context.CancelFunc
+}
+
+func (s *wsSession) serve(ctx context.Context) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Shutting down the server, or the client going away, stops every operation
+	go func() {
+		<-ctx.Done()
+		s.conn.Close()
+	}()
+
+	for {
+		bytes, err := s.conn.ReadMessage()
+		if err != nil {
+			if err != errWSClosed {
+				s.logger.Debug("graphql websocket closed: %s", err)
+			}
+			return
+		}
+
+		var msg gqlMessage
+		if err := json.Unmarshal(bytes, &msg); err != nil {
+			s.send(gqlMessage{Type: gqlConnectionError, Payload: errorPayload("malformed message: " + err.Error())})
+			continue
+		}
+
+		switch msg.Type {
+		case gqlConnectionInit:
+			if err := s.authenticate(ctx, msg.Payload); err != nil {
+				s.send(gqlMessage{Type: gqlConnectionError, Payload: errorPayload(err.Error())})
+				return
+			}
+			s.send(gqlMessage{Type: gqlConnectionAck})
+			s.keepAliveOnce.Do(func() { go s.keepAlive(ctx) })
+		case gqlStart:
+			s.start(ctx, msg)
+		case gqlStop:
+			s.stop(msg.ID)
+		case gqlConnectionTerminate:
+			return
+		default:
+			s.send(gqlMessage{ID: msg.ID, Type: gqlError, Payload: errorPayload("unknown message type `" + msg.Type + "`")})
+		}
+	}
+}
+
+// authenticate identifies the admin of a session which was not authenticated when upgraded
+func (s *wsSession) authenticate(ctx context.Context, payload json.RawMessage) error {
+
+	if s.principal != nil || !s.auth.required() {
+		return nil
+	}
+
+	var params gqlConnectionParams
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &params); err != nil {
+			return err
+		}
+	}
+
+	principal, err := s.auth.authenticateHeader(ctx, params.Authorization)
+	if err != nil && err != ErrInvalidCredentials {
+		s.logger.Error("failed to authenticate graphql websocket: %s", err)
+	}
+	if err != nil || principal == nil {
+		return ErrInvalidCredentials
+	}
+
+	s.principal = principal
+	return nil
+}
+
+func (s *wsSession) start(ctx context.Context, msg gqlMessage) {
+
+	if s.principal == nil && s.auth.required() {
+		s.send(gqlMessage{ID: msg.ID, Type: gqlError, Payload: errorPayload("authentication required")})
+		return
+	}
+
+	var request graphql.GraphQLRequest
+	if err := json.Unmarshal(msg.Payload, &request); err != nil {
+		s.send(gqlMessage{ID: msg.ID, Type: gqlError, Payload: errorPayload("malformed operation: " + err.Error())})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	op := &wsOperation{cancel}
+
+	s.mutex.Lock()
+	if _, has := s.operations[msg.ID]; has {
+		s.mutex.Unlock()
+		cancel()
+		s.send(gqlMessage{ID: msg.ID, Type: gqlError, Payload: errorPayload("operation `" + msg.ID + "` is already running")})
+		return
+	}
+	s.operations[msg.ID] = op
+	s.mutex.Unlock()
+
+	// Each operation is a request of its own in the audit log
+	ctx = audit.WithRequestID(s.auth.context(ctx, s.principal), audit.NewRequestID())
+
+	responses := graphql.Subscribe(ctx, s.nk, &request)
+
+	go func() {
+		for resp := range responses {
+			payload, err := json.Marshal(resp)
+			if err != nil {
+				s.logger.Error("failed to encode graphql response: %s", err)
+				continue
+			}
+			s.send(gqlMessage{ID: msg.ID, Type: gqlData, Payload: payload})
+		}
+		// A stopped operation is not completed, the client already forgot it
+		if s.finish(msg.ID, op) {
+			s.send(gqlMessage{ID: msg.ID, Type: gqlComplete})
+		}
+	}()
+}
+
+// stop cancels the operation running with id, if any
+func (s *wsSession) stop(id string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if op, has := s.operations[id]; has {
+		op.cancel()
+		delete(s.operations, id)
+	}
+}
+
+// finish forgets an operation which ended, returning false if it was stopped before, even if its id was reused since
+func (s *wsSession) finish(id string, op *wsOperation) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	op.cancel()
+	if s.operations[id] != op {
+		return false
+	}
+	delete(s.operations, id)
+	return true
+}
+
+func (s *wsSession) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.send(gqlMessage{Type: gqlConnectionKeepAlive})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *wsSession) send(msg gqlMessage) {
+	bytes, err := json.Marshal(msg)
+	if err == nil {
+		err = s.conn.WriteMessage(bytes)
+	}
+	if err != nil {
+		s.logger.Debug("failed to send graphql websocket message: %s", err)
+	}
+}
+
+func errorPayload(message string) json.RawMessage {
+	payload, _ := json.Marshal(map[string]string{"message": message})
+	return payload
+}
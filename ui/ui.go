@@ -1,20 +1,109 @@
 package ui
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/gobuffalo/packr"
 	"github.com/heroiclabs/nakama/runtime"
+
+	"github.com/mastern2k3/poseidon/graphql"
 )
 
-func RegisterUI(init runtime.Initializer) error {
-	go func() {
-		statics := packr.NewBox("./static")
+// The assets GraphiQL needs are downloaded, checked against their pinned integrity hashes and packed in the binary
+//go:generate go run ./internal/fetchassets
+//go:generate packr
+
+// minSessionKeySize is the minimum size of the key signing session tokens
+const minSessionKeySize = 32
+
+// Options configure the ui server
+type Options struct {
+	// Authenticators identify the admin making each GraphQL request, and are tried in order. Requests need not be
+	// authenticated when there are none, which only suits servers whose port no one else can reach.
+	Authenticators []Authenticator
+	// Roles lists the root mutations each role may execute, graphql.AllMutations allowing every one of them,
+	// while admins whose role is not listed may only query. Admins may execute every mutation when Roles is nil.
+	Roles map[string][]string
+	// AllowedOrigins lists the origins, such as `https://altair.example.com`, of the browser based clients allowed to
+	// call `/graphql` from outside the ui. Requests sent by browsers from any other site are refused, and only
+	// servers whose admins all authenticate with tokens let every site read responses.
+	AllowedOrigins []string
+
+	// Address is the host and port the server listens on, or only a port. When empty it is read from the
+	// POSEIDON_UI_ADDRESS environment variable, then from `poseidon_ui_address` in Nakama's runtime env,
+	// and is DefaultAddress otherwise.
+	Address string
+	// CertFile and KeyFile serve the ui over TLS when both are set, and are read like Address from
+	// POSEIDON_UI_CERT_FILE and POSEIDON_UI_KEY_FILE, or `poseidon_ui_cert_file` and `poseidon_ui_key_file`.
+	CertFile string
+	KeyFile  string
+	// ShutdownTimeout is how long requests in flight are waited for once the context given at init is done,
+	// DefaultShutdownTimeout when zero
+	ShutdownTimeout time.Duration
+}
+
+// RegisterUI serves GraphiQL, along with a `/graphql` endpoint executing GraphQL operations sent with GET, POST or
+// over a websocket, subscriptions included, with the NakamaModule and database given at init. It returns an error if
+// the server cannot listen, and shuts the server down once ctx is done.
+func RegisterUI(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, init runtime.Initializer, opts Options) error {
+	_, _, err := start(ctx, logger, db, nk, opts)
+	return err
+}
+
+// start listens and serves the ui in the background, returning the address it listens on and a channel closed
+// once the server has shut down
+func start(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, opts Options) (net.Addr, <-chan struct{}, error) {
+
+	auth := &authorizer{nk: nk, authenticators: opts.Authenticators, roles: opts.Roles, allowedOrigins: opts.AllowedOrigins}
+
+	var sessions *SessionTokens
+	for _, authenticator := range opts.Authenticators {
+		if b, ok := authenticator.(*BasicAuth); ok {
+			graphql.ReserveCollection(b.collection())
+		}
+		if s, ok := authenticator.(*SessionTokens); ok {
+			if len(s.Key) < minSessionKeySize {
+				return nil, nil, fmt.Errorf("session tokens must be signed with a key of at least %d bytes", minSessionKeySize)
+			}
+			sessions = s
+		}
+	}
+
+	if !auth.required() {
+		logger.Warn("the ui server is not authenticated, anyone reaching its port can read and change data through graphql")
+	}
+
+	statics := packr.NewBox("./static")
+
+	if err := checkAssets(statics.Find); err != nil {
+		return nil, nil, err
+	}
+
+	listener, err := listen(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ready int32
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(statics))
+	mux.Handle("/graphql", graphqlHandler(ctx, logger, db, nk, auth))
+	if sessions != nil {
+		mux.Handle("/auth/login", auth.loginHandler(logger, sessions))
+	}
+	mux.Handle("/healthz", healthHandler(logger))
+	mux.Handle("/readyz", readyHandler(logger, db, &ready))
+
+	logger.Info("serving the ui on %s", listener.Addr())
 
-		srv := http.NewServeMux()
-		srv.Handle("/", http.FileServer(statics))
+	stopped := make(chan struct{})
+	go serve(ctx, logger, &http.Server{Handler: mux}, listener, &ready, opts.ShutdownTimeout, stopped)
 
-		http.ListenAndServe(":8090", srv)
-	}()
-	return nil
+	return listener.Addr(), stopped, nil
 }
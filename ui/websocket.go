@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// A minimal server side websocket (RFC 6455), only exchanging text messages.
+// Plugins must share the versions of their dependencies with the Nakama binary loading them,
+// so the websocket library Nakama vendors cannot be pinned independently here.
+
+const (
+	wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xa
+
+	wsCloseNormal      = 1000
+	wsCloseProtocol    = 1002
+	wsCloseUnsupported = 1003
+	wsCloseTooBig      = 1009
+
+	// wsMaxMessageSize bounds the size of the messages read, which are GraphQL requests
+	wsMaxMessageSize = 1 << 20
+)
+
+var (
+	errWSClosed = errors.New("websocket closed")
+)
+
+type wsConn struct {
+	conn       net.Conn
+	reader     *bufio.Reader
+	writeMutex sync.Mutex
+	closeOnce  sync.Once
+}
+
+// isWebsocketUpgrade tells whether r asks to switch to the websocket protocol
+func isWebsocketUpgrade(r *http.Request) bool {
+	return headerContains(r.Header, "Connection", "upgrade") && headerContains(r.Header, "Upgrade", "websocket")
+}
+
+// upgradeWebsocket completes the websocket handshake of r, agreeing on protocol if the client offers it
+func upgradeWebsocket(w http.ResponseWriter, r *http.Request, protocol string) (*wsConn, error) {
+
+	key := r.Header.Get("Sec-Websocket-Key")
+
+	if r.Method != http.MethodGet || !isWebsocketUpgrade(r) || key == "" {
+		http.Error(w, "expected a websocket upgrade", http.StatusBadRequest)
+		return nil, errors.New("not a websocket upgrade request")
+	}
+
+	if r.Header.Get("Sec-Websocket-Version") != "13" {
+		w.Header().Set("Sec-Websocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("unsupported websocket version `%s`", r.Header.Get("Sec-Websocket-Version"))
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websockets are not supported", http.StatusInternalServerError)
+		return nil, errors.New("the response writer cannot be hijacked")
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	accept := sha1.Sum([]byte(key + wsGUID))
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(accept[:]) + "\r\n"
+	if headerContains(r.Header, "Sec-Websocket-Protocol", protocol) {
+		response += "Sec-WebSocket-Protocol: " + protocol + "\r\n"
+	}
+
+	if _, err := conn.Write([]byte(response + "\r\n")); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &wsConn{conn: conn, reader: rw.Reader}, nil
+}
+
+// ReadMessage returns the next text message, answering pings on the way, and errWSClosed once the client closes
+func (c *wsConn) ReadMessage() ([]byte, error) {
+
+	var message []byte
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return nil, err
+			}
+		case wsOpPong:
+		case wsOpClose:
+			c.close(wsCloseNormal)
+			return nil, errWSClosed
+		case wsOpBinary:
+			c.close(wsCloseUnsupported)
+			return nil, errors.New("binary websocket messages are not supported")
+		case wsOpText, wsOpContinuation:
+			if (opcode == wsOpText) != (message == nil) {
+				c.close(wsCloseProtocol)
+				return nil, errors.New("unexpected websocket message fragment")
+			}
+			if len(message)+len(payload) > wsMaxMessageSize {
+				c.close(wsCloseTooBig)
+				return nil, fmt.Errorf("websocket message exceeds %d bytes", wsMaxMessageSize)
+			}
+			message = append(append([]byte{}, message...), payload...)
+			if fin {
+				return message, nil
+			}
+		default:
+			c.close(wsCloseProtocol)
+			return nil, fmt.Errorf("unknown websocket opcode %d", opcode)
+		}
+	}
+}
+
+// WriteMessage sends a text message, it can be called concurrently
+func (c *wsConn) WriteMessage(message []byte) error {
+	return c.writeFrame(wsOpText, message)
+}
+
+// Close closes the connection, telling the client it was closed normally
+func (c *wsConn) Close() error {
+	c.close(wsCloseNormal)
+	return nil
+}
+
+func (c *wsConn) close(code uint16) {
+	c.closeOnce.Do(func() {
+		payload := make([]byte, 2)
+		binary.BigEndian.PutUint16(payload, code)
+		c.writeFrame(wsOpClose, payload)
+		c.conn.Close()
+	})
+}
+
+func (c *wsConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+
+	header := make([]byte, 2)
+	if _, err = io.ReadFull(c.reader, header); err != nil {
+		return
+	}
+
+	fin, opcode = header[0]&0x80 != 0, header[0]&0x0f
+	masked, length := header[1]&0x80 != 0, uint64(header[1]&0x7f)
+
+	if !masked {
+		c.close(wsCloseProtocol)
+		return false, 0, nil, errors.New("client websocket frames must be masked")
+	}
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err = io.ReadFull(c.reader, extended); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err = io.ReadFull(c.reader, extended); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if length > wsMaxMessageSize {
+		c.close(wsCloseTooBig)
+		return false, 0, nil, fmt.Errorf("websocket frame exceeds %d bytes", wsMaxMessageSize)
+	}
+
+	mask := make([]byte, 4)
+	if _, err = io.ReadFull(c.reader, mask); err != nil {
+		return
+	}
+
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.reader, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+
+	frame := []byte{0x80 | opcode}
+
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, byte(length))
+	case length <= 0xffff:
+		frame = append(frame, 126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(length))
+	default:
+		frame = append(frame, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(length))
+	}
+
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	_, err := c.conn.Write(append(frame, payload...))
+	return err
+}
+
+// headerContains tells whether one of the comma separated values of a header is value, ignoring case
+func headerContains(header http.Header, name string, value string) bool {
+	for _, line := range header[http.CanonicalHeaderKey(name)] {
+		for _, v := range strings.Split(line, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), value) {
+				return true
+			}
+		}
+	}
+	return false
+}